@@ -0,0 +1,304 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const alertGroupPath = "alert_groups"
+
+type AlertGroup struct {
+	ID            string
+	IntegrationID string
+	RouteID       string
+	TeamID        string
+	AlertsCount   int
+	State         AlertGroupState
+	Title         string
+	CreatedAt     time.Time
+	//AcknowledgedAt is the zero time if the alert group is not acknowledged
+	AcknowledgedAt time.Time
+	//AcknowledgedBy is the ID of the user who acknowledged the alert group
+	AcknowledgedBy string
+	//ResolvedAt is the zero time if the alert group is not resolved
+	ResolvedAt time.Time
+	//ResolvedBy is the ID of the user who resolved the alert group
+	ResolvedBy string
+	Permalinks AlertGroupPermalinks
+}
+
+type alertGroupRaw struct {
+	ID             string               `json:"id"`
+	IntegrationID  string               `json:"integration_id"`
+	RouteID        string               `json:"route_id"`
+	TeamID         string               `json:"team_id"`
+	AlertsCount    int                  `json:"alerts_count"`
+	State          AlertGroupState      `json:"state"`
+	Title          string               `json:"title"`
+	CreatedAt      string               `json:"created_at"`
+	AcknowledgedAt string               `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string               `json:"acknowledged_by,omitempty"`
+	ResolvedAt     string               `json:"resolved_at,omitempty"`
+	ResolvedBy     string               `json:"resolved_by,omitempty"`
+	Permalinks     AlertGroupPermalinks `json:"permalinks"`
+}
+
+func (a *AlertGroup) UnmarshalJSON(b []byte) error {
+	raw := alertGroupRaw{}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	*a = AlertGroup{
+		ID:             raw.ID,
+		IntegrationID:  raw.IntegrationID,
+		RouteID:        raw.RouteID,
+		TeamID:         raw.TeamID,
+		AlertsCount:    raw.AlertsCount,
+		State:          raw.State,
+		Title:          raw.Title,
+		AcknowledgedBy: raw.AcknowledgedBy,
+		ResolvedBy:     raw.ResolvedBy,
+		Permalinks:     raw.Permalinks,
+	}
+
+	a.CreatedAt, err = timeFromString(raw.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	a.AcknowledgedAt, err = optionalTimeFromString(raw.AcknowledgedAt)
+	if err != nil {
+		return err
+	}
+
+	a.ResolvedAt, err = optionalTimeFromString(raw.ResolvedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *AlertGroup) MarshalJSON() ([]byte, error) {
+	if a == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(&alertGroupRaw{
+		ID:             a.ID,
+		IntegrationID:  a.IntegrationID,
+		RouteID:        a.RouteID,
+		TeamID:         a.TeamID,
+		AlertsCount:    a.AlertsCount,
+		State:          a.State,
+		Title:          a.Title,
+		CreatedAt:      timeToString(a.CreatedAt),
+		AcknowledgedAt: optionalTimeToString(a.AcknowledgedAt),
+		AcknowledgedBy: a.AcknowledgedBy,
+		ResolvedAt:     optionalTimeToString(a.ResolvedAt),
+		ResolvedBy:     a.ResolvedBy,
+		Permalinks:     a.Permalinks,
+	})
+}
+
+type AlertGroupPermalinks struct {
+	Slack    string `json:"slack"`
+	Telegram string `json:"telegram"`
+	Web      string `json:"web"`
+}
+
+type AlertGroupState string
+
+const (
+	AlertGroupStateNew          AlertGroupState = "new"
+	AlertGroupStateAcknowledged AlertGroupState = "acknowledged"
+	AlertGroupStateResolved     AlertGroupState = "resolved"
+	AlertGroupStateSilenced     AlertGroupState = "silenced"
+)
+
+const alertGroupStartedAtLayout = "2006-01-02T15:04:05"
+
+type AlertGroupFilter struct {
+	IntegrationID string
+	RouteID       string
+	TeamID        string
+	State         AlertGroupState
+	//If StartedAfter or StartedBefore are non-zero, only alert groups started
+	//within the range are returned. A zero bound leaves that side of the range
+	//open.
+	StartedAfter  time.Time
+	StartedBefore time.Time
+}
+
+func (f *AlertGroupFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.IntegrationID != "" {
+			values.Set("integration_id", f.IntegrationID)
+		}
+
+		if f.RouteID != "" {
+			values.Set("route_id", f.RouteID)
+		}
+
+		if f.TeamID != "" {
+			values.Set("team_id", f.TeamID)
+		}
+
+		if f.State != "" {
+			values.Set("state", string(f.State))
+		}
+
+		if !f.StartedAfter.IsZero() || !f.StartedBefore.IsZero() {
+			after := time.Unix(0, 0)
+			if !f.StartedAfter.IsZero() {
+				after = f.StartedAfter
+			}
+
+			before := time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+			if !f.StartedBefore.IsZero() {
+				before = f.StartedBefore
+			}
+
+			values.Set("started_at", fmt.Sprintf(
+				"%s_%s",
+				after.UTC().Format(alertGroupStartedAtLayout),
+				before.UTC().Format(alertGroupStartedAtLayout),
+			))
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListAlertGroupsByPage(
+	page int,
+	filter *AlertGroupFilter,
+) (*PaginatedResponse[AlertGroup], error) {
+	return c.ListAlertGroupsByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListAlertGroupsByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *AlertGroupFilter,
+) (*PaginatedResponse[AlertGroup], error) {
+	return getPage[AlertGroup](ctx, c, page, alertGroupPath, filter.values())
+}
+
+func (c *Client) ListAlertGroups(filter *AlertGroupFilter) ([]AlertGroup, error) {
+	return c.ListAlertGroupsCtx(context.Background(), filter)
+}
+
+func (c *Client) ListAlertGroupsCtx(
+	ctx context.Context,
+	filter *AlertGroupFilter,
+) ([]AlertGroup, error) {
+	return paginate(c.AlertGroupsIterCtx(ctx, filter))
+}
+
+func (c *Client) AlertGroupsIter(filter *AlertGroupFilter) *Iterator[AlertGroup] {
+	return c.AlertGroupsIterCtx(context.Background(), filter)
+}
+
+func (c *Client) AlertGroupsIterCtx(
+	ctx context.Context,
+	filter *AlertGroupFilter,
+) *Iterator[AlertGroup] {
+	return newIterator[AlertGroup](ctx, c, alertGroupPath, filter.values())
+}
+
+func (c *Client) GetAlertGroup(id string) (*AlertGroup, error) {
+	return c.GetAlertGroupCtx(context.Background(), id)
+}
+
+func (c *Client) GetAlertGroupCtx(ctx context.Context, id string) (*AlertGroup, error) {
+	ret := &AlertGroup{}
+	err := c.doRequest(ctx, "GET", buildPath(alertGroupPath, id), nil, ret)
+	return ret, err
+}
+
+func (c *Client) AcknowledgeAlertGroup(id string) error {
+	return c.AcknowledgeAlertGroupCtx(context.Background(), id)
+}
+
+func (c *Client) AcknowledgeAlertGroupCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "POST", buildPath(alertGroupPath, id, "acknowledge"), nil, nil)
+}
+
+func (c *Client) UnacknowledgeAlertGroup(id string) error {
+	return c.UnacknowledgeAlertGroupCtx(context.Background(), id)
+}
+
+func (c *Client) UnacknowledgeAlertGroupCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "POST", buildPath(alertGroupPath, id, "unacknowledge"), nil, nil)
+}
+
+func (c *Client) ResolveAlertGroup(id string) error {
+	return c.ResolveAlertGroupCtx(context.Background(), id)
+}
+
+func (c *Client) ResolveAlertGroupCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "POST", buildPath(alertGroupPath, id, "resolve"), nil, nil)
+}
+
+func (c *Client) UnresolveAlertGroup(id string) error {
+	return c.UnresolveAlertGroupCtx(context.Background(), id)
+}
+
+func (c *Client) UnresolveAlertGroupCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "POST", buildPath(alertGroupPath, id, "unresolve"), nil, nil)
+}
+
+// SilenceAlertGroup silences the alert group for the given duration, which is
+// sent to the API with a precision of seconds. A negative duration silences the
+// alert group until it is unsilenced.
+func (c *Client) SilenceAlertGroup(id string, duration time.Duration) error {
+	return c.SilenceAlertGroupCtx(context.Background(), id, duration)
+}
+
+func (c *Client) SilenceAlertGroupCtx(
+	ctx context.Context,
+	id string,
+	duration time.Duration,
+) error {
+
+	requestBody := struct {
+		Delay int `json:"delay"`
+	}{
+		Delay: -1,
+	}
+
+	if duration >= 0 {
+		requestBody.Delay = int(duration.Seconds())
+	}
+
+	return c.doRequest(
+		ctx,
+		"POST",
+		buildPath(alertGroupPath, id, "silence"),
+		&requestBody,
+		nil,
+	)
+}
+
+func (c *Client) UnsilenceAlertGroup(id string) error {
+	return c.UnsilenceAlertGroupCtx(context.Background(), id)
+}
+
+func (c *Client) UnsilenceAlertGroupCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "POST", buildPath(alertGroupPath, id, "unsilence"), nil, nil)
+}
+
+func (c *Client) DeleteAlertGroup(id string) error {
+	return c.DeleteAlertGroupCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteAlertGroupCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(alertGroupPath, id), nil, nil)
+}
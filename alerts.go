@@ -1,6 +1,7 @@
 package oncall
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"time"
@@ -62,21 +63,45 @@ type ListAlertFilter struct {
 	Search       string
 }
 
-func (c *Client) ListAlertsByPage(page int, filter *ListAlertFilter) (*PaginatedResponse[Alert], error) {
+func (f *ListAlertFilter) values() url.Values {
 	values := url.Values{}
-	if filter != nil {
-		if filter.AlertGroupID != "" {
-			values.Set("alert_group_id", filter.AlertGroupID)
+	if f != nil {
+		if f.AlertGroupID != "" {
+			values.Set("alert_group_id", f.AlertGroupID)
 		}
 
-		if filter.Search != "" {
-			values.Set("search", filter.Search)
+		if f.Search != "" {
+			values.Set("search", f.Search)
 		}
 	}
 
-	return getPage[Alert](c, page, alertPath, values)
+	return values
+}
+
+func (c *Client) ListAlertsByPage(page int, filter *ListAlertFilter) (*PaginatedResponse[Alert], error) {
+	return c.ListAlertsByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListAlertsByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *ListAlertFilter,
+) (*PaginatedResponse[Alert], error) {
+	return getPage[Alert](ctx, c, page, alertPath, filter.values())
 }
 
 func (c *Client) ListAlerts(filter *ListAlertFilter) ([]Alert, error) {
-	return paginate(c.ListAlertsByPage, filter)
+	return c.ListAlertsCtx(context.Background(), filter)
+}
+
+func (c *Client) ListAlertsCtx(ctx context.Context, filter *ListAlertFilter) ([]Alert, error) {
+	return paginate(c.AlertsIterCtx(ctx, filter))
+}
+
+func (c *Client) AlertsIter(filter *ListAlertFilter) *Iterator[Alert] {
+	return c.AlertsIterCtx(context.Background(), filter)
+}
+
+func (c *Client) AlertsIterCtx(ctx context.Context, filter *ListAlertFilter) *Iterator[Alert] {
+	return newIterator[Alert](ctx, c, alertPath, filter.values())
 }
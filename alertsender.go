@@ -0,0 +1,331 @@
+package oncall
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AlertSender sends alerts to the inbound URL of an integration, which is the
+// Link of an Integration. Formatted webhook integrations accept alerts sent
+// with Send, and Alertmanager integrations accept alerts sent with
+// SendAlertmanager. The inbound URL authenticates the sender, so no AuthToken
+// is needed.
+type AlertSender struct {
+	URL string
+	//If Client is nil, http.DefaultClient will be used
+	Client *http.Client
+	//If Retry is non-nil, sends are retried according to the policy. Sends are
+	//retried on transport errors and 5xx responses even though they are POSTs,
+	//because the integration deduplicates alerts by their UID or fingerprint.
+	Retry *RetryPolicy
+	//If RateLimiter is non-nil, every send, including retries, waits for the
+	//limiter before being sent.
+	RateLimiter *RateLimiter
+}
+
+// InboundAlertState is whether an alert sent to an integration is firing or
+// resolved. Sending a resolved alert with the UID of a firing alert resolves
+// its alert group, if the integration is configured to do so.
+type InboundAlertState string
+
+const (
+	InboundAlertStateFiring   InboundAlertState = "alerting"
+	InboundAlertStateResolved InboundAlertState = "ok"
+)
+
+// InboundAlert is an alert in the format of a formatted webhook integration.
+type InboundAlert struct {
+	//UID is the deduplication key of the alert. Alerts with the same UID are
+	//grouped together.
+	UID                   string            `json:"alert_uid"`
+	Title                 string            `json:"title"`
+	Message               string            `json:"message,omitempty"`
+	ImageURL              string            `json:"image_url,omitempty"`
+	LinkToUpstreamDetails string            `json:"link_to_upstream_details,omitempty"`
+	State                 InboundAlertState `json:"state"`
+}
+
+func (s *AlertSender) Send(alert *InboundAlert) error {
+	return s.SendCtx(context.Background(), alert)
+}
+
+func (s *AlertSender) SendCtx(ctx context.Context, alert *InboundAlert) error {
+	return s.post(ctx, alert)
+}
+
+// AlertmanagerAlert is an alert in the format of an Alertmanager webhook.
+type AlertmanagerAlert struct {
+	State       InboundAlertState
+	Labels      map[string]string
+	Annotations map[string]string
+	StartsAt    time.Time
+	//EndsAt is the zero time if the alert has not ended
+	EndsAt       time.Time
+	GeneratorURL string
+	//Fingerprint is the deduplication key of the alert. If empty, it is
+	//computed from the labels.
+	Fingerprint string
+}
+
+type alertmanagerAlertRaw struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     string            `json:"startsAt"`
+	EndsAt       string            `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+type alertmanagerPayloadRaw struct {
+	Version           string                 `json:"version"`
+	GroupKey          string                 `json:"groupKey"`
+	TruncatedAlerts   int                    `json:"truncatedAlerts"`
+	Status            string                 `json:"status"`
+	Receiver          string                 `json:"receiver"`
+	GroupLabels       map[string]string      `json:"groupLabels"`
+	CommonLabels      map[string]string      `json:"commonLabels"`
+	CommonAnnotations map[string]string      `json:"commonAnnotations"`
+	ExternalURL       string                 `json:"externalURL"`
+	Alerts            []alertmanagerAlertRaw `json:"alerts"`
+}
+
+// alertmanagerZeroTime is how Alertmanager represents an unset EndsAt
+const alertmanagerZeroTime = "0001-01-01T00:00:00Z"
+
+func alertmanagerStatus(state InboundAlertState) string {
+	if state == InboundAlertStateResolved {
+		return "resolved"
+	}
+
+	return "firing"
+}
+
+// alertmanagerFingerprint hashes the sorted labels, so that alerts with the
+// same labels are deduplicated.
+func alertmanagerFingerprint(labels map[string]string) string {
+	hash := sha256.New()
+	for _, name := range sortedKeys(labels) {
+		fmt.Fprintf(hash, "%s\xff%s\xff", name, labels[name])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+// SendAlertmanager sends the alerts as one Alertmanager notification. Alerts
+// with the same groupKey are grouped together. If groupKey is empty, it is
+// computed from the labels common to all of the alerts.
+func (s *AlertSender) SendAlertmanager(groupKey string, alerts []AlertmanagerAlert) error {
+	return s.SendAlertmanagerCtx(context.Background(), groupKey, alerts)
+}
+
+func (s *AlertSender) SendAlertmanagerCtx(
+	ctx context.Context,
+	groupKey string,
+	alerts []AlertmanagerAlert,
+) error {
+
+	payload := alertmanagerPayloadRaw{
+		Version:           "4",
+		GroupKey:          groupKey,
+		Status:            "resolved",
+		GroupLabels:       map[string]string{},
+		CommonLabels:      map[string]string{},
+		CommonAnnotations: map[string]string{},
+		Alerts:            make([]alertmanagerAlertRaw, len(alerts)),
+	}
+
+	for i, alert := range alerts {
+		raw := alertmanagerAlertRaw{
+			Status:       alertmanagerStatus(alert.State),
+			Labels:       alert.Labels,
+			Annotations:  alert.Annotations,
+			StartsAt:     alertmanagerZeroTime,
+			EndsAt:       alertmanagerZeroTime,
+			GeneratorURL: alert.GeneratorURL,
+			Fingerprint:  alert.Fingerprint,
+		}
+
+		if raw.Labels == nil {
+			raw.Labels = map[string]string{}
+		}
+
+		if raw.Annotations == nil {
+			raw.Annotations = map[string]string{}
+		}
+
+		if !alert.StartsAt.IsZero() {
+			raw.StartsAt = alert.StartsAt.UTC().Format(time.RFC3339Nano)
+		}
+
+		if !alert.EndsAt.IsZero() {
+			raw.EndsAt = alert.EndsAt.UTC().Format(time.RFC3339Nano)
+		}
+
+		if raw.Fingerprint == "" {
+			raw.Fingerprint = alertmanagerFingerprint(raw.Labels)
+		}
+
+		if raw.Status == "firing" {
+			payload.Status = "firing"
+		}
+
+		payload.Alerts[i] = raw
+	}
+
+	if len(alerts) > 0 {
+		payload.CommonLabels = commonValues(alerts, func(a AlertmanagerAlert) map[string]string {
+			return a.Labels
+		})
+		payload.CommonAnnotations = commonValues(alerts, func(a AlertmanagerAlert) map[string]string {
+			return a.Annotations
+		})
+	}
+
+	if payload.GroupKey == "" {
+		pairs := make([]string, 0, len(payload.CommonLabels))
+		for _, name := range sortedKeys(payload.CommonLabels) {
+			pairs = append(pairs, fmt.Sprintf("%s=%q", name, payload.CommonLabels[name]))
+		}
+
+		payload.GroupKey = fmt.Sprintf("{}:{%s}", strings.Join(pairs, ", "))
+		payload.GroupLabels = payload.CommonLabels
+	}
+
+	return s.post(ctx, &payload)
+}
+
+// commonValues returns the key/value pairs which all of the alerts share in
+// the map returned by get.
+func commonValues(
+	alerts []AlertmanagerAlert,
+	get func(AlertmanagerAlert) map[string]string,
+) map[string]string {
+
+	ret := map[string]string{}
+	for name, value := range get(alerts[0]) {
+		ret[name] = value
+	}
+
+	for _, alert := range alerts[1:] {
+		values := get(alert)
+		for name, value := range ret {
+			if otherValue, found := values[name]; !found || otherValue != value {
+				delete(ret, name)
+			}
+		}
+	}
+
+	return ret
+}
+
+func sortedKeys(m map[string]string) []string {
+	ret := make([]string, 0, len(m))
+	for key := range m {
+		ret = append(ret, key)
+	}
+
+	sort.Strings(ret)
+	return ret
+}
+
+// post sends the payload as JSON to the URL of the sender. Non-2xx responses
+// are returned as an *APIError.
+func (s *AlertSender) post(ctx context.Context, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	u, err := parseInboundURL(s.URL)
+	if err != nil {
+		return err
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	//Alerts are deduplicated by the integration, so the POST is safe to retry
+	var retry *RetryPolicy
+	if s.Retry != nil {
+		policy := *s.Retry
+		policy.RetryNonIdempotent = true
+		retry = &policy
+	}
+
+	resp, err := retry.do(ctx, "POST", func() (*http.Response, error) {
+		if _, err := s.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		return resp, redactInboundURLError(err, u)
+	})
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return newAPIError("POST", redactInboundURL(u), resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// redactInboundURL removes the path and query of a URL which carries a secret,
+// like the token of an integration URL or an iCal export, so that it can be
+// shown in errors.
+func redactInboundURL(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+// redactInboundURLError replaces the URL in the *url.Error returned by
+// http.Client.Do with the redacted URL. Other errors are returned unchanged.
+func redactInboundURLError(err error, u *url.URL) error {
+	urlErr := &url.Error{}
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	redacted := *urlErr
+	redacted.URL = redactInboundURL(u)
+	return &redacted
+}
+
+// parseInboundURL parses a URL which carries a secret without returning it in
+// errors.
+func parseInboundURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		urlErr := &url.Error{}
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		return nil, fmt.Errorf("parsing URL: %w", err)
+	}
+
+	return u, nil
+}
@@ -2,6 +2,7 @@ package oncall
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,6 +22,13 @@ type Client struct {
 	//If Trace is non-nil, information about HTTP requests will be given into the
 	//Writer.
 	Trace io.Writer
+	//If Retry is non-nil, requests that fail with a transport error, a 429, or
+	//a transient 5xx will be retried according to the policy. If nil, each
+	//request is attempted once.
+	Retry *RetryPolicy
+	//If RateLimiter is non-nil, every request, including retries, waits for the
+	//limiter before being sent.
+	RateLimiter *RateLimiter
 }
 
 type PaginatedResponse[T any] struct {
@@ -38,8 +46,9 @@ type PaginatedResponse[T any] struct {
 }
 
 // URL encoded values can be given as a *url.Values as "input" when performing
-// a GET call
+// a GET call. Non-2xx responses are returned as an *APIError.
 func (c *Client) doRequest(
+	ctx context.Context,
 	method, path string,
 	input interface{},
 	output interface{}) error {
@@ -59,7 +68,7 @@ func (c *Client) doRequest(
 		}
 	}
 
-	resp, err := c.Curl(method, path, query, body)
+	resp, err := c.CurlCtx(ctx, method, path, query, body)
 	if err != nil {
 		return err
 	}
@@ -69,7 +78,8 @@ func (c *Client) doRequest(
 	}()
 
 	if resp.StatusCode/100 != 2 {
-		return fmt.Errorf("non-2xx response code: %s", resp.Status)
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(method, path, resp.StatusCode, respBody)
 	}
 
 	if output != nil {
@@ -84,8 +94,22 @@ func (c *Client) doRequest(
 
 // Curl takes the given path, prepends <URL>/api/v1/ to it, and makes the request
 // with the remainder of the given parameters. Errors returned only reflect
-// transport errors, not HTTP semantic errors
+// transport errors, not HTTP semantic errors. If the Client has a Retry policy,
+// the response to the final attempt is returned.
 func (c *Client) Curl(method string, path string, urlQuery url.Values, body io.Reader) (*http.Response, error) {
+	return c.CurlCtx(context.Background(), method, path, urlQuery, body)
+}
+
+// CurlCtx is Curl, but the request is bound to the given context. Cancelling
+// the context aborts the request.
+func (c *Client) CurlCtx(
+	ctx context.Context,
+	method string,
+	path string,
+	urlQuery url.Values,
+	body io.Reader,
+) (*http.Response, error) {
+
 	//Setup URL
 	u := *c.URL
 	pathPrefix := strings.Trim(u.Path, "/")
@@ -98,8 +122,38 @@ func (c *Client) Curl(method string, path string, urlQuery url.Values, body io.R
 	}
 	u.RawQuery = urlQuery.Encode()
 
-	//Do the request
-	req, err := http.NewRequest(method, u.String(), body)
+	//Buffer the body so that it can be replayed if the request is retried
+	var bodyBytes []byte
+	if body != nil && c.Retry != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.Retry.do(ctx, method, func() (*http.Response, error) {
+		if _, err := c.RateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptBody := body
+		if bodyBytes != nil {
+			attemptBody = bytes.NewReader(bodyBytes)
+		}
+
+		return c.send(ctx, method, u.String(), attemptBody)
+	})
+}
+
+func (c *Client) send(
+	ctx context.Context,
+	method string,
+	u string,
+	body io.Reader,
+) (*http.Response, error) {
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +170,10 @@ func (c *Client) Curl(method string, path string, urlQuery url.Values, body io.R
 	}
 
 	if client.CheckRedirect == nil {
+		//Copy the client so that concurrent requests don't race on setting this,
+		//and so that a shared client like http.DefaultClient isn't modified
+		clientCopy := *client
+		client = &clientCopy
 		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
 			if len(via) > 10 {
 				return fmt.Errorf("stopped after 10 redirects")
@@ -0,0 +1,69 @@
+package oncall
+
+import (
+	"context"
+)
+
+const directPagePath = "escalation"
+
+// DirectPageUser is a user to page directly. Important users are notified
+// with their important notification rules.
+type DirectPageUser struct {
+	ID        string `json:"id"`
+	Important bool   `json:"important"`
+}
+
+type DirectPageOptions struct {
+	Message   string
+	SourceURL string
+	//TeamID is the team to page. At least one of TeamID or Users must be given.
+	TeamID string
+	//Important is whether the team is paged with its important escalation
+	Important bool
+	Users     []DirectPageUser
+	//If AlertGroupID is given, the users and team are added as responders to
+	//that alert group instead of creating a new one, and the title is ignored.
+	AlertGroupID string
+}
+
+// DirectPage pages users and/or a team, and returns the alert group created
+// for the page. The returned alert group can be followed with the alert group
+// methods of the Client.
+func (c *Client) DirectPage(title string, opts *DirectPageOptions) (*AlertGroup, error) {
+	return c.DirectPageCtx(context.Background(), title, opts)
+}
+
+func (c *Client) DirectPageCtx(
+	ctx context.Context,
+	title string,
+	opts *DirectPageOptions,
+) (*AlertGroup, error) {
+
+	requestBody := struct {
+		Title        string           `json:"title,omitempty"`
+		Message      string           `json:"message,omitempty"`
+		SourceURL    string           `json:"source_url,omitempty"`
+		Team         string           `json:"team,omitempty"`
+		Important    bool             `json:"important_team_escalation"`
+		Users        []DirectPageUser `json:"users,omitempty"`
+		AlertGroupID string           `json:"alert_group_id,omitempty"`
+	}{
+		Title: title,
+	}
+
+	if opts != nil {
+		requestBody.Message = opts.Message
+		requestBody.SourceURL = opts.SourceURL
+		requestBody.Team = opts.TeamID
+		requestBody.Important = opts.Important
+		requestBody.Users = opts.Users
+		requestBody.AlertGroupID = opts.AlertGroupID
+		if opts.AlertGroupID != "" {
+			requestBody.Title = ""
+		}
+	}
+
+	ret := &AlertGroup{}
+	err := c.doRequest(ctx, "POST", directPagePath, &requestBody, ret)
+	return ret, err
+}
@@ -0,0 +1,130 @@
+package oncall
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned when the OnCall API responds with a non-2xx status
+// code. Use errors.As to retrieve it from an error returned by a Client
+// method.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	//Body is the raw body of the response, if any.
+	Body []byte
+	//Detail is the "detail" message returned by the API, if any.
+	Detail string
+	//FieldErrors maps request field names to the validation errors the API
+	//returned for them. Errors that do not apply to a specific field are keyed
+	//under "non_field_errors".
+	FieldErrors map[string][]string
+}
+
+func newAPIError(method, path string, statusCode int, body []byte) *APIError {
+	ret := &APIError{
+		Method:     strings.ToUpper(method),
+		Path:       path,
+		StatusCode: statusCode,
+		Body:       body,
+	}
+
+	raw := map[string]json.RawMessage{}
+	if json.Unmarshal(body, &raw) != nil {
+		return ret
+	}
+
+	for field, value := range raw {
+		if field == "detail" {
+			_ = json.Unmarshal(value, &ret.Detail)
+			continue
+		}
+
+		var msgs []string
+		if json.Unmarshal(value, &msgs) != nil {
+			var msg string
+			if json.Unmarshal(value, &msg) != nil {
+				continue
+			}
+			msgs = []string{msg}
+		}
+
+		if ret.FieldErrors == nil {
+			ret.FieldErrors = map[string][]string{}
+		}
+		ret.FieldErrors[field] = msgs
+	}
+
+	return ret
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf(
+		"%s %s: %d %s",
+		e.Method,
+		e.Path,
+		e.StatusCode,
+		http.StatusText(e.StatusCode),
+	)
+
+	if e.Detail != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Detail)
+	}
+
+	if len(e.FieldErrors) > 0 {
+		fields := make([]string, 0, len(e.FieldErrors))
+		for field := range e.FieldErrors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		fieldMsgs := make([]string, 0, len(fields))
+		for _, field := range fields {
+			fieldMsgs = append(
+				fieldMsgs,
+				fmt.Sprintf("%s: %s", field, strings.Join(e.FieldErrors[field], " ")),
+			)
+		}
+
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(fieldMsgs, "; "))
+	}
+
+	return msg
+}
+
+func hasStatusCode(err error, codes ...int) bool {
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsNotFound returns true if err is an *APIError with a 404 status code.
+func IsNotFound(err error) bool { return hasStatusCode(err, http.StatusNotFound) }
+
+// IsRateLimited returns true if err is an *APIError with a 429 status code.
+func IsRateLimited(err error) bool { return hasStatusCode(err, http.StatusTooManyRequests) }
+
+// IsUnauthorized returns true if err is an *APIError with a 401 or 403 status
+// code.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// IsBadRequest returns true if err is an *APIError with a 400 status code.
+// FieldErrors on the *APIError will usually describe what was wrong with the
+// request.
+func IsBadRequest(err error) bool { return hasStatusCode(err, http.StatusBadRequest) }
@@ -1,6 +1,7 @@
 package oncall
 
 import (
+	"context"
 	"net/url"
 )
 
@@ -13,21 +14,61 @@ type EscalationChain struct {
 }
 
 type ListEscalationChainsFilter struct {
-	//This is empty, but here for backwards-compatibility if they implement
-	//filters in the future
+	TeamID string
+}
+
+func (f *ListEscalationChainsFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.TeamID != "" {
+			values.Set("team_id", f.TeamID)
+		}
+	}
+
+	return values
 }
 
 func (c *Client) ListEscalationChainsByPage(page int, filter *ListEscalationChainsFilter) (*PaginatedResponse[EscalationChain], error) {
-	return getPage[EscalationChain](c, page, escChainPath, url.Values{})
+	return c.ListEscalationChainsByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListEscalationChainsByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *ListEscalationChainsFilter,
+) (*PaginatedResponse[EscalationChain], error) {
+	return getPage[EscalationChain](ctx, c, page, escChainPath, filter.values())
 }
 
 func (c *Client) ListEscalationChains(filter *ListEscalationChainsFilter) ([]EscalationChain, error) {
-	return paginate(c.ListEscalationChainsByPage, filter)
+	return c.ListEscalationChainsCtx(context.Background(), filter)
+}
+
+func (c *Client) ListEscalationChainsCtx(
+	ctx context.Context,
+	filter *ListEscalationChainsFilter,
+) ([]EscalationChain, error) {
+	return paginate(c.EscalationChainsIterCtx(ctx, filter))
+}
+
+func (c *Client) EscalationChainsIter(filter *ListEscalationChainsFilter) *Iterator[EscalationChain] {
+	return c.EscalationChainsIterCtx(context.Background(), filter)
+}
+
+func (c *Client) EscalationChainsIterCtx(
+	ctx context.Context,
+	filter *ListEscalationChainsFilter,
+) *Iterator[EscalationChain] {
+	return newIterator[EscalationChain](ctx, c, escChainPath, filter.values())
 }
 
 func (c *Client) GetEscalationChain(id string) (*EscalationChain, error) {
+	return c.GetEscalationChainCtx(context.Background(), id)
+}
+
+func (c *Client) GetEscalationChainCtx(ctx context.Context, id string) (*EscalationChain, error) {
 	ret := &EscalationChain{}
-	err := c.doRequest("GET", buildPath(escChainPath, id), nil, ret)
+	err := c.doRequest(ctx, "GET", buildPath(escChainPath, id), nil, ret)
 	return ret, err
 }
 
@@ -39,6 +80,14 @@ func (c *Client) CreateEscalationChain(
 	name string,
 	opts *CreateEscalationChainOptions,
 ) (*EscalationChain, error) {
+	return c.CreateEscalationChainCtx(context.Background(), name, opts)
+}
+
+func (c *Client) CreateEscalationChainCtx(
+	ctx context.Context,
+	name string,
+	opts *CreateEscalationChainOptions,
+) (*EscalationChain, error) {
 
 	requestBody := struct {
 		Name   string `json:"name"`
@@ -55,10 +104,49 @@ func (c *Client) CreateEscalationChain(
 
 	ret := &EscalationChain{}
 
-	err := c.doRequest("POST", "escalation_chains", &requestBody, ret)
+	err := c.doRequest(ctx, "POST", "escalation_chains", &requestBody, ret)
+	return ret, err
+}
+
+// UpdateEscalationChainOptions holds the fields to change on an escalation
+// chain. Only non-nil fields are sent.
+type UpdateEscalationChainOptions struct {
+	Name   *string
+	TeamID *string
+}
+
+func (c *Client) UpdateEscalationChain(
+	id string,
+	opts *UpdateEscalationChainOptions,
+) (*EscalationChain, error) {
+	return c.UpdateEscalationChainCtx(context.Background(), id, opts)
+}
+
+func (c *Client) UpdateEscalationChainCtx(
+	ctx context.Context,
+	id string,
+	opts *UpdateEscalationChainOptions,
+) (*EscalationChain, error) {
+
+	requestBody := struct {
+		Name   *string `json:"name,omitempty"`
+		TeamID *string `json:"team_id,omitempty"`
+	}{}
+
+	if opts != nil {
+		requestBody.Name = opts.Name
+		requestBody.TeamID = opts.TeamID
+	}
+
+	ret := &EscalationChain{}
+	err := c.doRequest(ctx, "PUT", buildPath(escChainPath, id), &requestBody, ret)
 	return ret, err
 }
 
 func (c *Client) DeleteEscalationChain(id string) error {
-	return c.doRequest("DELETE", buildPath(escChainPath, id), nil, nil)
+	return c.DeleteEscalationChainCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteEscalationChainCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(escChainPath, id), nil, nil)
 }
@@ -1,6 +1,7 @@
 package oncall
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"strings"
@@ -192,6 +193,7 @@ func (e *EscalationPolicyRuleNotifyUserGroup) EscalationPolicyType() EscalationP
 }
 
 type EscalationPolicyRuleTriggerAction struct {
+	//ActionID is the ID of an OutgoingWebhook
 	ActionID string `json:"action_to_trigger"`
 }
 
@@ -259,9 +261,19 @@ func (c *Client) CreateEscalationPolicy(
 	position int,
 	rule EscalationPolicyRule,
 ) (*EscalationPolicy, error) {
+	return c.CreateEscalationPolicyCtx(context.Background(), escChainID, position, rule)
+}
+
+func (c *Client) CreateEscalationPolicyCtx(
+	ctx context.Context,
+	escChainID string,
+	position int,
+	rule EscalationPolicyRule,
+) (*EscalationPolicy, error) {
 
 	ret := &EscalationPolicy{}
 	err := c.doRequest(
+		ctx,
 		"POST",
 		escPolicyPath,
 		&EscalationPolicy{
@@ -278,33 +290,120 @@ type EscalationPolicyFilter struct {
 	EscalationChainID string
 }
 
+func (f *EscalationPolicyFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.EscalationChainID != "" {
+			values.Set("escalation_chain_id", f.EscalationChainID)
+		}
+	}
+
+	return values
+}
+
 func (c *Client) ListEscalationPoliciesByPage(
 	page int,
 	filter *EscalationPolicyFilter,
 ) (*PaginatedResponse[EscalationPolicy], error) {
+	return c.ListEscalationPoliciesByPageCtx(context.Background(), page, filter)
+}
 
-	values := url.Values{}
-	if filter != nil {
-		if filter.EscalationChainID != "" {
-			values.Set("escalation_chain_id", filter.EscalationChainID)
-		}
-	}
-
-	return getPage[EscalationPolicy](c, page, escPolicyPath, values)
+func (c *Client) ListEscalationPoliciesByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *EscalationPolicyFilter,
+) (*PaginatedResponse[EscalationPolicy], error) {
+	return getPage[EscalationPolicy](ctx, c, page, escPolicyPath, filter.values())
 }
 
 func (c *Client) ListEscalationPolicies(
 	filter *EscalationPolicyFilter,
 ) ([]EscalationPolicy, error) {
-	return paginate(c.ListEscalationPoliciesByPage, filter)
+	return c.ListEscalationPoliciesCtx(context.Background(), filter)
+}
+
+func (c *Client) ListEscalationPoliciesCtx(
+	ctx context.Context,
+	filter *EscalationPolicyFilter,
+) ([]EscalationPolicy, error) {
+	return paginate(c.EscalationPoliciesIterCtx(ctx, filter))
+}
+
+func (c *Client) EscalationPoliciesIter(filter *EscalationPolicyFilter) *Iterator[EscalationPolicy] {
+	return c.EscalationPoliciesIterCtx(context.Background(), filter)
+}
+
+func (c *Client) EscalationPoliciesIterCtx(
+	ctx context.Context,
+	filter *EscalationPolicyFilter,
+) *Iterator[EscalationPolicy] {
+	return newIterator[EscalationPolicy](ctx, c, escPolicyPath, filter.values())
 }
 
 func (c *Client) GetEscalationPolicy(id string) (*EscalationPolicy, error) {
+	return c.GetEscalationPolicyCtx(context.Background(), id)
+}
+
+func (c *Client) GetEscalationPolicyCtx(ctx context.Context, id string) (*EscalationPolicy, error) {
 	ret := &EscalationPolicy{}
-	err := c.doRequest("GET", buildPath(escPolicyPath, id), nil, ret)
+	err := c.doRequest(ctx, "GET", buildPath(escPolicyPath, id), nil, ret)
+	return ret, err
+}
+
+// UpdateEscalationPolicyOptions holds the fields to change on an escalation
+// policy. Only non-nil fields are sent.
+type UpdateEscalationPolicyOptions struct {
+	//Position moves the policy within its escalation chain. The
+	//EscalationPolicyPosition constants may be used.
+	Position *int
+	//If Rule is non-nil, the policy's type and rule fields are replaced.
+	Rule EscalationPolicyRule
+}
+
+func (c *Client) UpdateEscalationPolicy(
+	id string,
+	opts *UpdateEscalationPolicyOptions,
+) (*EscalationPolicy, error) {
+	return c.UpdateEscalationPolicyCtx(context.Background(), id, opts)
+}
+
+func (c *Client) UpdateEscalationPolicyCtx(
+	ctx context.Context,
+	id string,
+	opts *UpdateEscalationPolicyOptions,
+) (*EscalationPolicy, error) {
+
+	requestBody := map[string]interface{}{}
+	if opts != nil {
+		if opts.Rule != nil {
+			inter, err := json.Marshal(&opts.Rule)
+			if err != nil {
+				return nil, err
+			}
+
+			err = json.Unmarshal(inter, &requestBody)
+			if err != nil {
+				return nil, err
+			}
+
+			requestBody["type"] = opts.Rule.EscalationPolicyType().String()
+		}
+
+		if opts.Position != nil {
+			requestBody["position"] = *opts.Position
+			requestBody["manual_order"] = true
+		}
+	}
+
+	ret := &EscalationPolicy{}
+	err := c.doRequest(ctx, "PUT", buildPath(escPolicyPath, id), &requestBody, ret)
 	return ret, err
 }
 
 func (c *Client) DeleteEscalationPolicy(id string) error {
-	return c.doRequest("DELETE", buildPath(escPolicyPath, id), nil, nil)
+	return c.DeleteEscalationPolicyCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteEscalationPolicyCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(escPolicyPath, id), nil, nil)
 }
@@ -0,0 +1,83 @@
+package oncall
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultHeartbeatInterval = time.Minute
+
+// Heartbeat requests the heartbeat link of an integration on an interval, so
+// that OnCall raises an alert if the process running it stops. The Interval
+// should be comfortably shorter than the heartbeat timeout of the integration.
+type Heartbeat struct {
+	//URL is the Link of an IntegrationHeartbeat
+	URL string
+	//Interval defaults to one minute
+	Interval time.Duration
+	//If Client is nil, http.DefaultClient will be used
+	Client *http.Client
+	//If OnError is non-nil, it is called with the error of each failed beat.
+	//Failed beats do not stop the Heartbeat.
+	OnError func(error)
+}
+
+// Run beats immediately and then on every Interval, until ctx is done. It
+// returns the error of ctx.
+func (h *Heartbeat) Run(ctx context.Context) error {
+	interval := h.Interval
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := h.Beat(ctx)
+		if err != nil && ctx.Err() == nil && h.OnError != nil {
+			h.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Beat requests the heartbeat URL once. Non-2xx responses are returned as an
+// *APIError.
+func (h *Heartbeat) Beat(ctx context.Context) error {
+	u, err := parseInboundURL(h.URL)
+	if err != nil {
+		return err
+	}
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return redactInboundURLError(err, u)
+	}
+
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return newAPIError("GET", redactInboundURL(u), resp.StatusCode, respBody)
+	}
+
+	return nil
+}
@@ -0,0 +1,498 @@
+package oncall
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ICalEvent is a VEVENT of an RFC 5545 iCalendar.
+type ICalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	//Recurrence is nil if the event does not repeat
+	Recurrence *ICalRecurrence
+	//ExDates are the starts of recurrences which are excluded
+	ExDates []time.Time
+	//RecurrenceID is non-zero if this event replaces the recurrence starting at
+	//RecurrenceID of the event with the same UID
+	RecurrenceID time.Time
+}
+
+type icalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// ParseICal parses the VEVENTs of an RFC 5545 iCalendar. VTIMEZONEs are only
+// used for TZIDs which are not in the tz database. Other components are
+// ignored.
+func ParseICal(r io.Reader) ([]ICalEvent, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	props := make([]icalProperty, len(lines))
+	for i, line := range lines {
+		props[i], err = parseICalProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+
+	zones := parseICalTimezones(props)
+
+	var ret []ICalEvent
+	var cur *ICalEvent
+	var duration time.Duration
+	var hasDuration bool
+	//allDay is whether DTSTART is a DATE
+	var allDay bool
+	var rrule string
+	//depth counts components nested inside the current VEVENT, like VALARMs
+	var depth int
+	for i, prop := range props {
+		switch {
+		case prop.Name == "BEGIN" && strings.EqualFold(prop.Value, "VEVENT") && cur == nil:
+			cur = &ICalEvent{}
+			duration = 0
+			hasDuration = false
+			allDay = false
+			rrule = ""
+			continue
+		case cur == nil:
+			continue
+		case prop.Name == "BEGIN":
+			depth++
+			continue
+		case prop.Name == "END" && depth > 0:
+			depth--
+			continue
+		case prop.Name == "END":
+			switch {
+			case !cur.End.IsZero():
+			case allDay && !hasDuration:
+				//An all-day event without an end lasts one day
+				cur.End = cur.Start.AddDate(0, 0, 1)
+			default:
+				cur.End = cur.Start.Add(duration)
+			}
+			if rrule != "" {
+				cur.Recurrence, err = parseICalRecurrence(rrule, cur.Start.Location())
+				if err != nil {
+					return nil, fmt.Errorf("VEVENT %q: RRULE: %w", cur.UID, err)
+				}
+			}
+			ret = append(ret, *cur)
+			cur = nil
+			continue
+		case depth > 0:
+			continue
+		}
+
+		switch prop.Name {
+		case "UID":
+			cur.UID = prop.Value
+		case "SUMMARY":
+			cur.Summary = unescapeICalText(prop.Value)
+		case "DESCRIPTION":
+			cur.Description = unescapeICalText(prop.Value)
+		case "DTSTART":
+			cur.Start, err = parseICalTime(prop, zones)
+			allDay = isICalDate(prop.Value, prop.Params["VALUE"])
+		case "DTEND":
+			cur.End, err = parseICalTime(prop, zones)
+		case "DURATION":
+			duration, err = parseICalDuration(prop.Value)
+			hasDuration = true
+		case "RRULE":
+			//Parsed at the end of the VEVENT, when DTSTART is known
+			rrule = prop.Value
+		case "EXDATE":
+			var exDates []time.Time
+			exDates, err = parseICalTimeList(prop, zones)
+			cur.ExDates = append(cur.ExDates, exDates...)
+		case "RECURRENCE-ID":
+			cur.RecurrenceID, err = parseICalTime(prop, zones)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", i+1, prop.Name, err)
+		}
+	}
+
+	if cur != nil {
+		return nil, fmt.Errorf("unterminated VEVENT")
+	}
+
+	return ret, nil
+}
+
+// unfoldICalLines reads content lines, joining lines which were folded by
+// starting continuation lines with whitespace.
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	var ret []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		if (line[0] == ' ' || line[0] == '\t') && len(ret) > 0 {
+			ret[len(ret)-1] += line[1:]
+			continue
+		}
+
+		ret = append(ret, line)
+	}
+
+	return ret, scanner.Err()
+}
+
+func parseICalProperty(line string) (icalProperty, error) {
+	ret := icalProperty{Params: map[string]string{}}
+
+	//Find the colon separating the value, skipping colons in quoted params
+	var inQuotes bool
+	valueStart := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			valueStart = i
+			break
+		}
+	}
+	if valueStart < 0 {
+		return ret, fmt.Errorf("no value in content line %q", line)
+	}
+
+	ret.Value = line[valueStart+1:]
+	parts := splitICalUnquoted(line[:valueStart], ';')
+	ret.Name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			return ret, fmt.Errorf("malformed parameter %q", param)
+		}
+
+		ret.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	return ret, nil
+}
+
+func splitICalUnquoted(s string, sep rune) []string {
+	var ret []string
+	var inQuotes bool
+	last := 0
+	for i, r := range s {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == sep && !inQuotes {
+			ret = append(ret, s[last:i])
+			last = i + 1
+		}
+	}
+
+	return append(ret, s[last:])
+}
+
+const (
+	icalDateLayout     = "20060102"
+	icalDateTimeLayout = "20060102T150405"
+)
+
+// parseICalTime parses a DATE or DATE-TIME property. Times with a TZID are
+// given in that location, and floating times are treated as UTC.
+func parseICalTime(prop icalProperty, zones icalZones) (time.Time, error) {
+	loc, err := prop.location(zones)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parseICalTimeValue(prop.Value, prop.Params["VALUE"], loc)
+}
+
+// parseICalTimeList parses a property holding a comma-separated list of DATE
+// or DATE-TIME values, like EXDATE.
+func parseICalTimeList(prop icalProperty, zones icalZones) ([]time.Time, error) {
+	loc, err := prop.location(zones)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []time.Time
+	for _, value := range strings.Split(prop.Value, ",") {
+		t, err := parseICalTimeValue(value, prop.Params["VALUE"], loc)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, t)
+	}
+
+	return ret, nil
+}
+
+func (p icalProperty) location(zones icalZones) (*time.Location, error) {
+	if tzid := p.Params["TZID"]; tzid != "" {
+		return zones.location(tzid)
+	}
+
+	return time.UTC, nil
+}
+
+func isICalDate(value, valueType string) bool {
+	return strings.EqualFold(valueType, "DATE") || len(value) == len(icalDateLayout)
+}
+
+func parseICalTimeValue(value, valueType string, loc *time.Location) (time.Time, error) {
+	if isICalDate(value, valueType) {
+		return time.ParseInLocation(icalDateLayout, value, loc)
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		return time.ParseInLocation(icalDateTimeLayout, strings.TrimSuffix(value, "Z"), time.UTC)
+	}
+
+	return time.ParseInLocation(icalDateTimeLayout, value, loc)
+}
+
+// parseICalDuration parses a DURATION value, like "PT8H" or "-P1DT30M".
+func parseICalDuration(s string) (time.Duration, error) {
+	orig := s
+	var sign time.Duration = 1
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+	}
+	s = strings.TrimLeft(s, "+-")
+
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("malformed duration %q", orig)
+	}
+	s = s[1:]
+
+	var ret time.Duration
+	var inTime bool
+	var num int
+	var haveNum bool
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num = num*10 + int(r-'0')
+			haveNum = true
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+
+		if !haveNum {
+			return 0, fmt.Errorf("malformed duration %q", orig)
+		}
+
+		unit := time.Duration(0)
+		switch {
+		case r == 'W' && !inTime:
+			unit = 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			unit = 24 * time.Hour
+		case r == 'H' && inTime:
+			unit = time.Hour
+		case r == 'M' && inTime:
+			unit = time.Minute
+		case r == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("malformed duration %q", orig)
+		}
+
+		ret += time.Duration(num) * unit
+		num = 0
+		haveNum = false
+	}
+
+	if haveNum {
+		return 0, fmt.Errorf("malformed duration %q", orig)
+	}
+
+	return sign * ret, nil
+}
+
+func unescapeICalText(s string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		if escaped {
+			switch r {
+			case 'n', 'N':
+				b.WriteRune('\n')
+			default:
+				b.WriteRune(r)
+			}
+			escaped = false
+			continue
+		}
+
+		if r == '\\' {
+			escaped = true
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+var icalTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// WriteICal writes the events as an RFC 5545 iCalendar.
+func WriteICal(w io.Writer, events []ICalEvent) error {
+	iw := &icalWriter{w: w}
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:-//go-oncall//EN")
+	iw.line("CALSCALE:GREGORIAN")
+
+	//Recurring events keep their location, so that they follow its daylight
+	//saving time. Everything else is written in UTC.
+	zones := map[string]*icalZoneRange{}
+	for _, event := range events {
+		if tzid, loc := icalEventZone(event); loc != nil {
+			zone, found := zones[tzid]
+			if !found {
+				zone = &icalZoneRange{loc: loc, from: event.Start, to: event.Start}
+				zones[tzid] = zone
+			}
+
+			zone.include(event.Start, event.End, event.Recurrence.Until)
+			zone.include(event.ExDates...)
+		}
+	}
+
+	tzids := make([]string, 0, len(zones))
+	for tzid := range zones {
+		tzids = append(tzids, tzid)
+	}
+	sort.Strings(tzids)
+	for _, tzid := range tzids {
+		writeICalTimezone(iw, tzid, zones[tzid])
+	}
+
+	stamp := time.Now().UTC().Format(icalDateTimeLayout) + "Z"
+	for _, event := range events {
+		timeProperty := icalUTCTimeProperty
+		if tzid, loc := icalEventZone(event); loc != nil {
+			timeProperty = func(name string, t time.Time) string {
+				return icalZonedTimeProperty(name, tzid, t.In(loc))
+			}
+		}
+
+		iw.line("BEGIN:VEVENT")
+		iw.line("UID:" + event.UID)
+		iw.line("DTSTAMP:" + stamp)
+		iw.line(timeProperty("DTSTART", event.Start))
+		iw.line(timeProperty("DTEND", event.End))
+		if event.Recurrence != nil {
+			iw.line("RRULE:" + event.Recurrence.String())
+		}
+		for _, exDate := range event.ExDates {
+			iw.line(timeProperty("EXDATE", exDate))
+		}
+		if !event.RecurrenceID.IsZero() {
+			iw.line(timeProperty("RECURRENCE-ID", event.RecurrenceID))
+		}
+		if event.Summary != "" {
+			iw.line("SUMMARY:" + icalTextEscaper.Replace(event.Summary))
+		}
+		if event.Description != "" {
+			iw.line("DESCRIPTION:" + icalTextEscaper.Replace(event.Description))
+		}
+		iw.line("END:VEVENT")
+	}
+
+	iw.line("END:VCALENDAR")
+	return iw.err
+}
+
+func icalUTCTimeProperty(name string, t time.Time) string {
+	return name + ":" + t.UTC().Format(icalDateTimeLayout) + "Z"
+}
+
+func icalZonedTimeProperty(name, tzid string, t time.Time) string {
+	if strings.ContainsAny(tzid, `:;,"`) {
+		tzid = `"` + strings.ReplaceAll(tzid, `"`, "") + `"`
+	}
+
+	return fmt.Sprintf("%s;TZID=%s:%s", name, tzid, t.Format(icalDateTimeLayout))
+}
+
+// WriteFinalShiftsICal writes the shifts as the VEVENTs of an RFC 5545
+// iCalendar. The summary of each event is the username of the user on call.
+func WriteFinalShiftsICal(w io.Writer, shifts []FinalShift) error {
+	events := make([]ICalEvent, len(shifts))
+	for i, shift := range shifts {
+		events[i] = ICalEvent{
+			UID:         fmt.Sprintf("%s-%d@go-oncall", shift.UserID, shift.Start.Unix()),
+			Summary:     shift.UserUsername,
+			Description: shift.UserEmail,
+			Start:       shift.Start,
+			End:         shift.End,
+		}
+	}
+
+	return WriteICal(w, events)
+}
+
+// icalWriter writes CRLF-terminated content lines, folding them at 75 octets.
+// After the first error, writes are skipped.
+type icalWriter struct {
+	w   io.Writer
+	err error
+}
+
+const icalMaxLineOctets = 75
+
+func (iw *icalWriter) line(s string) {
+	if iw.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	limit := icalMaxLineOctets
+	for len(s) > limit {
+		//Don't split a multi-byte character across lines
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		//Continuation lines spend one octet on the leading space
+		limit = icalMaxLineOctets - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+
+	_, iw.err = io.WriteString(iw.w, b.String())
+}
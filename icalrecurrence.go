@@ -0,0 +1,439 @@
+package oncall
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ICalRecurrence is a parsed RFC 5545 RRULE. The BYSETPOS, BYYEARDAY,
+// BYWEEKNO, BYHOUR, BYMINUTE, and BYSECOND rule parts are not supported.
+type ICalRecurrence struct {
+	Frequency ICalFrequency
+	//Interval is the number of Frequency units between repetitions. Values
+	//less than 1 are treated as 1.
+	Interval int
+	//Count is the number of occurrences, including the first. It is 0 if the
+	//number of occurrences is not limited by a count.
+	Count int
+	//Until is the zero time if the recurrence is not bounded by a time.
+	//Occurrences starting after Until are not generated.
+	Until      time.Time
+	ByDay      []ICalWeekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+}
+
+type ICalFrequency string
+
+const (
+	ICalFrequencyHourly  ICalFrequency = "HOURLY"
+	ICalFrequencyDaily   ICalFrequency = "DAILY"
+	ICalFrequencyWeekly  ICalFrequency = "WEEKLY"
+	ICalFrequencyMonthly ICalFrequency = "MONTHLY"
+	ICalFrequencyYearly  ICalFrequency = "YEARLY"
+)
+
+// ICalWeekday is a BYDAY value. If N is non-zero, it selects the Nth such
+// weekday of the month, counting from the end of the month if N is negative.
+type ICalWeekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// parseICalRecurrence parses the value of an RRULE. loc is the location of
+// the DTSTART of the event, which UNTIL is interpreted in if it is floating.
+func parseICalRecurrence(s string, loc *time.Location) (*ICalRecurrence, error) {
+	ret := &ICalRecurrence{WeekStart: time.Monday}
+	for _, part := range strings.Split(s, ";") {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			ret.Frequency = ICalFrequency(strings.ToUpper(value))
+			switch ret.Frequency {
+			case ICalFrequencyHourly,
+				ICalFrequencyDaily,
+				ICalFrequencyWeekly,
+				ICalFrequencyMonthly,
+				ICalFrequencyYearly:
+			default:
+				return nil, fmt.Errorf("unsupported frequency %q", value)
+			}
+		case "INTERVAL":
+			ret.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			ret.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			ret.Until, err = parseICalTimeValue(value, "", loc)
+			if err == nil && len(value) == len(icalDateLayout) {
+				//A DATE includes the whole day
+				ret.Until = ret.Until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+		case "WKST":
+			ret.WeekStart, err = parseICalWeekdayName(value)
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				var weekday ICalWeekday
+				weekday, err = parseICalWeekday(day)
+				if err != nil {
+					break
+				}
+				ret.ByDay = append(ret.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				var monthDay int
+				monthDay, err = strconv.Atoi(day)
+				if err != nil {
+					break
+				}
+				ret.ByMonthDay = append(ret.ByMonthDay, monthDay)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(value, ",") {
+				var monthNum int
+				monthNum, err = strconv.Atoi(month)
+				if err != nil {
+					break
+				}
+				ret.ByMonth = append(ret.ByMonth, time.Month(monthNum))
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	if ret.Frequency == "" {
+		return nil, fmt.Errorf("missing FREQ")
+	}
+
+	if ret.Frequency == ICalFrequencyYearly && len(ret.ByDay) > 0 && len(ret.ByMonth) == 0 {
+		return nil, fmt.Errorf("BYDAY in a YEARLY rule without BYMONTH is unsupported")
+	}
+
+	return ret, nil
+}
+
+// String returns the recurrence as the value of an RRULE. Until is written in
+// UTC.
+func (r *ICalRecurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(icalDateTimeLayout)+"Z")
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, byDay := range r.ByDay {
+			days[i] = weekdayToString(byDay.Weekday)
+			if byDay.N != 0 {
+				days[i] = strconv.Itoa(byDay.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, monthDay := range r.ByMonthDay {
+			days[i] = strconv.Itoa(monthDay)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, month := range r.ByMonth {
+			months[i] = strconv.Itoa(int(month))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayToString(r.WeekStart))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func parseICalWeekday(s string) (ICalWeekday, error) {
+	if len(s) < 2 {
+		return ICalWeekday{}, fmt.Errorf("malformed weekday %q", s)
+	}
+
+	ret := ICalWeekday{}
+	var err error
+	if ordinal := s[:len(s)-2]; ordinal != "" {
+		ret.N, err = strconv.Atoi(ordinal)
+		if err != nil {
+			return ret, fmt.Errorf("malformed weekday %q", s)
+		}
+	}
+
+	ret.Weekday, err = parseICalWeekdayName(s[len(s)-2:])
+	return ret, err
+}
+
+func parseICalWeekdayName(s string) (time.Weekday, error) {
+	s = strings.ToUpper(s)
+	for i, str := range weekdayStringLookup {
+		if str == s {
+			return time.Weekday(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+// starts returns the starts of the occurrences of a recurrence beginning at
+// dtstart which start before limit, in chronological order. dtstart is always
+// the first occurrence.
+func (r *ICalRecurrence) starts(dtstart, limit time.Time) []time.Time {
+	if !dtstart.Before(limit) {
+		return nil
+	}
+
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	loc := dtstart.Location()
+	year, month, day := dtstart.Date()
+	hour, min, sec := dtstart.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, dtstart.Nanosecond(), loc)
+	}
+
+	ret := []time.Time{dtstart}
+	for n := 0; r.Count <= 0 || len(ret) < r.Count; n++ {
+		//periodStart is no later than any candidate in the period
+		var periodStart time.Time
+		var candidates []time.Time
+		switch r.Frequency {
+		case ICalFrequencyHourly:
+			periodStart = dtstart.Add(time.Duration(n*interval) * time.Hour)
+			if r.matches(periodStart) {
+				candidates = append(candidates, periodStart)
+			}
+
+		case ICalFrequencyDaily:
+			periodStart = at(year, month, day+n*interval)
+			if r.matches(periodStart) {
+				candidates = append(candidates, periodStart)
+			}
+
+		case ICalFrequencyWeekly:
+			//Weeks begin on WeekStart, counting from the week dtstart is in
+			offset := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+			periodStart = at(year, month, day-offset+7*n*interval)
+			for i := 0; i < 7; i++ {
+				candidate := periodStart.AddDate(0, 0, i)
+				if r.matchesWeekday(candidate, dtstart.Weekday()) && r.matchesMonth(candidate) {
+					candidates = append(candidates, candidate)
+				}
+			}
+
+		case ICalFrequencyMonthly:
+			periodStart = at(year, month+time.Month(n*interval), 1)
+			periodYear, periodMonth, _ := periodStart.Date()
+			candidates = r.monthCandidates(periodYear, periodMonth, day, at)
+
+		case ICalFrequencyYearly:
+			months := r.ByMonth
+			if len(months) == 0 {
+				months = []time.Month{month}
+			}
+
+			periodStart = at(year+n*interval, 1, 1)
+			for _, m := range months {
+				candidates = append(candidates, r.monthCandidates(year+n*interval, m, day, at)...)
+			}
+
+		default:
+			return ret
+		}
+
+		if !periodStart.Before(limit) || (!r.Until.IsZero() && periodStart.After(r.Until)) {
+			return ret
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].Before(candidates[j])
+		})
+
+		for _, candidate := range candidates {
+			if !candidate.After(ret[len(ret)-1]) {
+				continue
+			}
+
+			if !candidate.Before(limit) ||
+				(!r.Until.IsZero() && candidate.After(r.Until)) ||
+				(r.Count > 0 && len(ret) >= r.Count) {
+				return ret
+			}
+
+			ret = append(ret, candidate)
+		}
+	}
+
+	return ret
+}
+
+// monthCandidates returns the occurrences within the given month, according
+// to BYMONTHDAY and BYDAY. If neither are given, the day of the month of
+// dtstart is used.
+func (r *ICalRecurrence) monthCandidates(
+	year int,
+	month time.Month,
+	defaultDay int,
+	at func(int, time.Month, int) time.Time,
+) []time.Time {
+
+	daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	var monthDays map[int]bool
+	if len(r.ByMonthDay) > 0 {
+		monthDays = map[int]bool{}
+		for _, monthDay := range r.ByMonthDay {
+			if monthDay < 0 {
+				monthDay = daysInMonth + 1 + monthDay
+			}
+
+			if monthDay >= 1 && monthDay <= daysInMonth {
+				monthDays[monthDay] = true
+			}
+		}
+	}
+
+	var weekDays map[int]bool
+	if len(r.ByDay) > 0 {
+		weekDays = map[int]bool{}
+		firstWeekday := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		for _, byDay := range r.ByDay {
+			var matching []int
+			first := 1 + (int(byDay.Weekday)-int(firstWeekday)+7)%7
+			for d := first; d <= daysInMonth; d += 7 {
+				matching = append(matching, d)
+			}
+
+			switch {
+			case byDay.N == 0:
+				for _, d := range matching {
+					weekDays[d] = true
+				}
+			case byDay.N > 0 && byDay.N <= len(matching):
+				weekDays[matching[byDay.N-1]] = true
+			case byDay.N < 0 && -byDay.N <= len(matching):
+				weekDays[matching[len(matching)+byDay.N]] = true
+			}
+		}
+	}
+
+	var ret []time.Time
+	for d := 1; d <= daysInMonth; d++ {
+		switch {
+		case monthDays != nil && weekDays != nil:
+			if !monthDays[d] || !weekDays[d] {
+				continue
+			}
+		case monthDays != nil:
+			if !monthDays[d] {
+				continue
+			}
+		case weekDays != nil:
+			if !weekDays[d] {
+				continue
+			}
+		default:
+			if d != defaultDay {
+				continue
+			}
+		}
+
+		candidate := at(year, month, d)
+		if r.matchesMonth(candidate) {
+			ret = append(ret, candidate)
+		}
+	}
+
+	return ret
+}
+
+// matches applies BYDAY, BYMONTHDAY, and BYMONTH as filters, as they are for
+// HOURLY and DAILY recurrences.
+func (r *ICalRecurrence) matches(t time.Time) bool {
+	if len(r.ByDay) > 0 && !r.matchesWeekday(t, t.Weekday()) {
+		return false
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		daysInMonth := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		var found bool
+		for _, monthDay := range r.ByMonthDay {
+			if monthDay < 0 {
+				monthDay = daysInMonth + 1 + monthDay
+			}
+
+			if monthDay == t.Day() {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return r.matchesMonth(t)
+}
+
+// matchesWeekday reports whether t falls on a BYDAY weekday, or on
+// defaultWeekday if BYDAY is not given.
+func (r *ICalRecurrence) matchesWeekday(t time.Time, defaultWeekday time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return t.Weekday() == defaultWeekday
+	}
+
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday == t.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *ICalRecurrence) matchesMonth(t time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+
+	for _, month := range r.ByMonth {
+		if month == t.Month() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,295 @@
+package oncall
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("loading %s: %s", name, err)
+	}
+
+	return loc
+}
+
+func TestParseICalRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *ICalRecurrence
+		wantErr bool
+	}{
+		{
+			name:  "weekly with week start",
+			value: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,SU;WKST=SU",
+			want: &ICalRecurrence{
+				Frequency: ICalFrequencyWeekly,
+				Interval:  2,
+				ByDay:     []ICalWeekday{{Weekday: time.Tuesday}, {Weekday: time.Sunday}},
+				WeekStart: time.Sunday,
+			},
+		},
+		{
+			name:  "ordinal weekdays",
+			value: "FREQ=MONTHLY;BYDAY=-1FR,+2MO;COUNT=4",
+			want: &ICalRecurrence{
+				Frequency: ICalFrequencyMonthly,
+				Count:     4,
+				ByDay:     []ICalWeekday{{Weekday: time.Friday, N: -1}, {Weekday: time.Monday, N: 2}},
+				WeekStart: time.Monday,
+			},
+		},
+		{
+			name:  "negative month days and months",
+			value: "freq=yearly;bymonth=2,8;bymonthday=1,-1",
+			want: &ICalRecurrence{
+				Frequency:  ICalFrequencyYearly,
+				ByMonthDay: []int{1, -1},
+				ByMonth:    []time.Month{time.February, time.August},
+				WeekStart:  time.Monday,
+			},
+		},
+		{
+			name:  "UNTIL date includes the whole day",
+			value: "FREQ=DAILY;UNTIL=20240301",
+			want: &ICalRecurrence{
+				Frequency: ICalFrequencyDaily,
+				Until:     time.Date(2024, 3, 1, 23, 59, 59, 999999999, time.UTC),
+				WeekStart: time.Monday,
+			},
+		},
+		{
+			name:  "UNTIL date-time in UTC",
+			value: "FREQ=DAILY;UNTIL=20240301T120000Z",
+			want: &ICalRecurrence{
+				Frequency: ICalFrequencyDaily,
+				Until:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+				WeekStart: time.Monday,
+			},
+		},
+		{name: "missing FREQ", value: "COUNT=3", wantErr: true},
+		{name: "unknown FREQ", value: "FREQ=SECONDLY", wantErr: true},
+		{name: "unsupported BYSETPOS", value: "FREQ=MONTHLY;BYDAY=MO;BYSETPOS=1", wantErr: true},
+		{name: "unsupported BYHOUR", value: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+		{name: "yearly BYDAY without BYMONTH", value: "FREQ=YEARLY;BYDAY=20MO", wantErr: true},
+		{name: "malformed part", value: "FREQ=DAILY;COUNT", wantErr: true},
+		{name: "malformed weekday", value: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseICalRecurrence(test.value, time.UTC)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestICalRecurrenceStarts(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		limit   time.Time
+		//want is formatted as "2006-01-02 15:04 MST" in the location of dtstart
+		want []string
+	}{
+		{
+			name:    "daily with count",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2024, 1, 30, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:    []string{"2024-01-30 09:00 UTC", "2024-01-31 09:00 UTC", "2024-02-01 09:00 UTC"},
+		},
+		{
+			name:    "daily keeps the wall clock across DST",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2024, 3, 30, 9, 0, 0, 0, berlin),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:    []string{"2024-03-30 09:00 CET", "2024-03-31 09:00 CEST", "2024-04-01 09:00 CEST"},
+		},
+		{
+			name:    "weekly across the end of DST",
+			rule:    "FREQ=WEEKLY;BYDAY=SU;COUNT=3",
+			dtstart: time.Date(2024, 10, 27, 1, 30, 0, 0, newYork),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:    []string{"2024-10-27 01:30 EDT", "2024-11-03 01:30 EDT", "2024-11-10 01:30 EST"},
+		},
+		{
+			name:    "weekly on several days",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=5",
+			dtstart: time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"2024-01-03 09:00 UTC",
+				"2024-01-05 09:00 UTC",
+				"2024-01-08 09:00 UTC",
+				"2024-01-10 09:00 UTC",
+				"2024-01-12 09:00 UTC",
+			},
+		},
+		{
+			//RFC 5545, section 3.8.5.3
+			name:    "biweekly with Monday week start",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			dtstart: time.Date(1997, 8, 5, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"1997-08-05 09:00 UTC",
+				"1997-08-10 09:00 UTC",
+				"1997-08-19 09:00 UTC",
+				"1997-08-24 09:00 UTC",
+			},
+		},
+		{
+			//RFC 5545, section 3.8.5.3
+			name:    "biweekly with Sunday week start",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			dtstart: time.Date(1997, 8, 5, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(1998, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"1997-08-05 09:00 UTC",
+				"1997-08-17 09:00 UTC",
+				"1997-08-19 09:00 UTC",
+				"1997-08-31 09:00 UTC",
+			},
+		},
+		{
+			name:    "UNTIL date is inclusive",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;UNTIL=20240214",
+			dtstart: time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"2024-01-03 09:00 UTC",
+				"2024-01-17 09:00 UTC",
+				"2024-01-31 09:00 UTC",
+				"2024-02-14 09:00 UTC",
+			},
+		},
+		{
+			name:    "last Friday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR;COUNT=4",
+			dtstart: time.Date(2024, 1, 26, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"2024-01-26 09:00 UTC",
+				"2024-02-23 09:00 UTC",
+				"2024-03-29 09:00 UTC",
+				"2024-04-26 09:00 UTC",
+			},
+		},
+		{
+			name:    "second Monday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=2MO;COUNT=3",
+			dtstart: time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:    []string{"2024-01-08 09:00 UTC", "2024-02-12 09:00 UTC", "2024-03-11 09:00 UTC"},
+		},
+		{
+			name:    "month day skips short months",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=4",
+			dtstart: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"2024-01-31 09:00 UTC",
+				"2024-03-31 09:00 UTC",
+				"2024-05-31 09:00 UTC",
+				"2024-07-31 09:00 UTC",
+			},
+		},
+		{
+			name:    "negative month day",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			dtstart: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:    []string{"2024-01-31 09:00 UTC", "2024-02-29 09:00 UTC", "2024-03-31 09:00 UTC"},
+		},
+		{
+			name:    "leap day",
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29;COUNT=3",
+			dtstart: time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:    []string{"2024-02-29 09:00 UTC", "2028-02-29 09:00 UTC", "2032-02-29 09:00 UTC"},
+		},
+		{
+			name:    "hourly filtered by weekday",
+			rule:    "FREQ=HOURLY;INTERVAL=8;BYDAY=WE;COUNT=4",
+			dtstart: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{
+				"2024-01-03 00:00 UTC",
+				"2024-01-03 08:00 UTC",
+				"2024-01-03 16:00 UTC",
+				"2024-01-10 00:00 UTC",
+			},
+		},
+		{
+			name:    "limit is exclusive",
+			rule:    "FREQ=DAILY",
+			dtstart: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC),
+			want:    []string{"2024-01-01 09:00 UTC", "2024-01-02 09:00 UTC"},
+		},
+		{
+			name:    "dtstart is the first occurrence even if it doesn't match",
+			rule:    "FREQ=WEEKLY;BYDAY=MO;COUNT=2",
+			dtstart: time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC),
+			limit:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:    []string{"2024-01-03 09:00 UTC", "2024-01-08 09:00 UTC"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, err := parseICalRecurrence(test.rule, test.dtstart.Location())
+			if err != nil {
+				t.Fatalf("parsing rule: %s", err)
+			}
+
+			var got []string
+			for _, start := range rule.starts(test.dtstart, test.limit) {
+				got = append(got, start.Format("2006-01-02 15:04 MST"))
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestICalRecurrenceString(t *testing.T) {
+	for _, value := range []string{
+		"FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+		"FREQ=MONTHLY;UNTIL=20240301T120000Z;BYDAY=-1FR",
+		"FREQ=YEARLY;BYMONTHDAY=1,-1;BYMONTH=2,8",
+	} {
+		rule, err := parseICalRecurrence(value, time.UTC)
+		if err != nil {
+			t.Fatalf("parsing %q: %s", value, err)
+		}
+
+		if got := rule.String(); got != value {
+			t.Errorf("got %q, want %q", got, value)
+		}
+	}
+}
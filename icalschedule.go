@@ -0,0 +1,248 @@
+package oncall
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Occurrences returns the occurrences of the event which overlap the interval
+// from start to end, excluding its ExDates. The returned events do not recur.
+func (e ICalEvent) Occurrences(start, end time.Time) []ICalEvent {
+	if e.Recurrence == nil {
+		if icalOverlaps(e.Start, e.End, start, end) {
+			return []ICalEvent{e}
+		}
+
+		return nil
+	}
+
+	duration := e.End.Sub(e.Start)
+	var ret []ICalEvent
+	for _, occurrenceStart := range e.Recurrence.starts(e.Start, end) {
+		if e.isExcluded(occurrenceStart) {
+			continue
+		}
+
+		occurrence := e
+		occurrence.Start = occurrenceStart
+		occurrence.End = occurrenceStart.Add(duration)
+		occurrence.Recurrence = nil
+		occurrence.ExDates = nil
+		if icalOverlaps(occurrence.Start, occurrence.End, start, end) {
+			ret = append(ret, occurrence)
+		}
+	}
+
+	return ret
+}
+
+func (e ICalEvent) isExcluded(t time.Time) bool {
+	for _, exDate := range e.ExDates {
+		if exDate.Equal(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// icalOverlaps reports whether an event from eventStart to eventEnd overlaps
+// the interval from start to end. An event with no duration overlaps the
+// interval if it starts within it.
+func icalOverlaps(eventStart, eventEnd, start, end time.Time) bool {
+	if !eventStart.Before(end) {
+		return false
+	}
+
+	if eventEnd.After(eventStart) {
+		return eventEnd.After(start)
+	}
+
+	return !eventStart.Before(start)
+}
+
+// ExpandICalEvents returns the occurrences of the events which overlap the
+// interval from start to end, sorted by start. Events with a RecurrenceID
+// replace the occurrence of the event with the same UID which they identify.
+func ExpandICalEvents(events []ICalEvent, start, end time.Time) []ICalEvent {
+	type occurrenceKey struct {
+		uid   string
+		start int64
+	}
+
+	overridden := map[occurrenceKey]bool{}
+	for _, event := range events {
+		if !event.RecurrenceID.IsZero() {
+			overridden[occurrenceKey{event.UID, event.RecurrenceID.UnixNano()}] = true
+		}
+	}
+
+	var ret []ICalEvent
+	for _, event := range events {
+		for _, occurrence := range event.Occurrences(start, end) {
+			if event.RecurrenceID.IsZero() &&
+				overridden[occurrenceKey{event.UID, occurrence.Start.UnixNano()}] {
+				continue
+			}
+
+			ret = append(ret, occurrence)
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Start.Before(ret[j].Start)
+	})
+
+	return ret
+}
+
+// ICalSchedule is the calendars of a schedule with a ScheduleCalendarICal,
+// as OnCall reads them. The summary of each event lists the usernames on
+// call, separated by whitespace, optionally preceded by a priority level like
+// "[L1]". Only the events with the highest priority level are used, and
+// overrides take precedence over the primary calendar.
+type ICalSchedule struct {
+	Primary   []ICalEvent
+	Overrides []ICalEvent
+}
+
+var icalPriorityRegexp = regexp.MustCompile(`^\s*\[L(\d+)\]`)
+
+// OnCallAt returns the usernames of the users on call at t.
+func (s *ICalSchedule) OnCallAt(t time.Time) []string {
+	if users := icalOnCallAt(s.Overrides, t); len(users) > 0 {
+		return users
+	}
+
+	return icalOnCallAt(s.Primary, t)
+}
+
+func icalOnCallAt(events []ICalEvent, t time.Time) []string {
+	highestPriority := -1
+	var ret []string
+	seen := map[string]bool{}
+	for _, event := range ExpandICalEvents(events, t, t.Add(time.Nanosecond)) {
+		if !event.End.After(t) {
+			continue
+		}
+
+		priority := 0
+		summary := event.Summary
+		if match := icalPriorityRegexp.FindStringSubmatch(summary); match != nil {
+			priority, _ = strconv.Atoi(match[1])
+			summary = summary[len(match[0]):]
+		}
+
+		users := strings.Fields(summary)
+		if len(users) == 0 || priority < highestPriority {
+			continue
+		}
+
+		if priority > highestPriority {
+			highestPriority = priority
+			ret = nil
+			seen = map[string]bool{}
+		}
+
+		for _, user := range users {
+			if !seen[user] {
+				seen[user] = true
+				ret = append(ret, user)
+			}
+		}
+	}
+
+	return ret
+}
+
+// FetchICal fetches and parses the iCalendar at icalURL, like the
+// PrimaryURL of a ScheduleCalendarICal or the ICalOverridesURL of a Schedule.
+// The request is made with the http.Client and RetryPolicy of the Client, but
+// without its AuthToken. webcal:// URLs are fetched over HTTPS. Non-2xx
+// responses are returned as an *APIError.
+func (c *Client) FetchICal(icalURL string) ([]ICalEvent, error) {
+	return c.FetchICalCtx(context.Background(), icalURL)
+}
+
+func (c *Client) FetchICalCtx(ctx context.Context, icalURL string) ([]ICalEvent, error) {
+	//iCal URLs usually carry a secret in their path or query, so errors only
+	//show the scheme and host
+	u, err := parseInboundURL(icalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(u.Scheme, "webcal") {
+		u.Scheme = "https"
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := c.Retry.do(ctx, "GET", func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		return resp, redactInboundURLError(err, u)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError("GET", redactInboundURL(u), resp.StatusCode, respBody)
+	}
+
+	events, err := ParseICal(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", redactInboundURL(u), err)
+	}
+
+	return events, nil
+}
+
+// FetchICalSchedule fetches the primary and overrides calendars of a
+// schedule with FetchICal. Either URL can be empty.
+func (c *Client) FetchICalSchedule(primaryURL, overridesURL string) (*ICalSchedule, error) {
+	return c.FetchICalScheduleCtx(context.Background(), primaryURL, overridesURL)
+}
+
+func (c *Client) FetchICalScheduleCtx(
+	ctx context.Context,
+	primaryURL string,
+	overridesURL string,
+) (*ICalSchedule, error) {
+
+	ret := &ICalSchedule{}
+	var err error
+	if primaryURL != "" {
+		ret.Primary, err = c.FetchICalCtx(ctx, primaryURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if overridesURL != "" {
+		ret.Overrides, err = c.FetchICalCtx(ctx, overridesURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
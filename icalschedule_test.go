@@ -0,0 +1,350 @@
+package oncall
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPrimaryICal has a daily 09:00-17:00 Berlin shift which crosses the start
+// of DST on 2024-03-31, with one occurrence excluded and one moved, under an
+// all-year lower priority shift.
+const testPrimaryICal = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:day
+SUMMARY:[L1] alice bob
+DTSTART;TZID=Europe/Berlin:20240325T090000
+DTEND;TZID=Europe/Berlin:20240325T170000
+RRULE:FREQ=DAILY;COUNT=10
+EXDATE;TZID=Europe/Berlin:20240327T090000
+END:VEVENT
+BEGIN:VEVENT
+UID:day
+SUMMARY:[L1] carol
+RECURRENCE-ID;TZID=Europe/Berlin:20240328T090000
+DTSTART;TZID=Europe/Berlin:20240328T120000
+DTEND;TZID=Europe/Berlin:20240328T200000
+END:VEVENT
+BEGIN:VEVENT
+UID:fallback
+SUMMARY:dave
+DTSTART:20240101T000000Z
+DTEND:20250101T000000Z
+END:VEVENT
+END:VCALENDAR
+`
+
+const testOverridesICal = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:override
+SUMMARY:erin
+DTSTART:20240402T070000Z
+DTEND:20240402T080000Z
+END:VEVENT
+END:VCALENDAR
+`
+
+func mustParseICal(t *testing.T, s string) []ICalEvent {
+	t.Helper()
+	events, err := ParseICal(strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("parsing iCalendar: %s", err)
+	}
+
+	return events
+}
+
+func TestExpandICalEvents(t *testing.T) {
+	events := mustParseICal(t, testPrimaryICal)[:2]
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		//want is formatted as "summary start-end" in Berlin
+		want []string
+	}{
+		{
+			name:  "EXDATE, RECURRENCE-ID and DST",
+			start: time.Date(2024, 3, 26, 0, 0, 0, 0, berlin),
+			end:   time.Date(2024, 4, 2, 0, 0, 0, 0, berlin),
+			want: []string{
+				"[L1] alice bob 03-26 09:00 CET-17:00 CET",
+				"[L1] carol 03-28 12:00 CET-20:00 CET",
+				"[L1] alice bob 03-29 09:00 CET-17:00 CET",
+				"[L1] alice bob 03-30 09:00 CET-17:00 CET",
+				"[L1] alice bob 03-31 09:00 CEST-17:00 CEST",
+				"[L1] alice bob 04-01 09:00 CEST-17:00 CEST",
+			},
+		},
+		{
+			name:  "overlapping the start of the window",
+			start: time.Date(2024, 4, 3, 16, 0, 0, 0, berlin),
+			end:   time.Date(2024, 5, 1, 0, 0, 0, 0, berlin),
+			want:  []string{"[L1] alice bob 04-03 09:00 CEST-17:00 CEST"},
+		},
+		{
+			name:  "after COUNT",
+			start: time.Date(2024, 4, 4, 0, 0, 0, 0, berlin),
+			end:   time.Date(2024, 5, 1, 0, 0, 0, 0, berlin),
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, event := range ExpandICalEvents(events, test.start, test.end) {
+				if event.Recurrence != nil {
+					t.Errorf("occurrence %q still recurs", event.Summary)
+				}
+
+				got = append(got, event.Summary+" "+
+					event.Start.In(berlin).Format("01-02 15:04 MST")+"-"+
+					event.End.In(berlin).Format("15:04 MST"))
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestICalScheduleOnCallAt(t *testing.T) {
+	schedule := &ICalSchedule{
+		Primary:   mustParseICal(t, testPrimaryICal),
+		Overrides: mustParseICal(t, testOverridesICal),
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want []string
+	}{
+		{
+			name: "higher priority wins",
+			at:   time.Date(2024, 3, 26, 8, 0, 0, 0, time.UTC),
+			want: []string{"alice", "bob"},
+		},
+		{
+			name: "lower priority outside the shift",
+			at:   time.Date(2024, 3, 26, 16, 0, 0, 0, time.UTC),
+			want: []string{"dave"},
+		},
+		{
+			name: "excluded occurrence",
+			at:   time.Date(2024, 3, 27, 8, 0, 0, 0, time.UTC),
+			want: []string{"dave"},
+		},
+		{
+			name: "moved occurrence before its new start",
+			at:   time.Date(2024, 3, 28, 8, 30, 0, 0, time.UTC),
+			want: []string{"dave"},
+		},
+		{
+			name: "moved occurrence",
+			at:   time.Date(2024, 3, 28, 18, 0, 0, 0, time.UTC),
+			want: []string{"carol"},
+		},
+		{
+			//09:30 in Berlin is 08:30 UTC before DST starts
+			name: "before DST",
+			at:   time.Date(2024, 3, 30, 7, 30, 0, 0, time.UTC),
+			want: []string{"dave"},
+		},
+		{
+			//and 07:30 UTC after
+			name: "after DST",
+			at:   time.Date(2024, 4, 1, 7, 30, 0, 0, time.UTC),
+			want: []string{"alice", "bob"},
+		},
+		{
+			name: "override",
+			at:   time.Date(2024, 4, 2, 7, 30, 0, 0, time.UTC),
+			want: []string{"erin"},
+		},
+		{
+			name: "end is exclusive",
+			at:   time.Date(2024, 4, 2, 8, 0, 0, 0, time.UTC),
+			want: []string{"alice", "bob"},
+		},
+		{
+			name: "nobody",
+			at:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := schedule.OnCallAt(test.at); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseICalAllDayEvent(t *testing.T) {
+	events := mustParseICal(t, `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:all-day
+SUMMARY:alice
+DTSTART;VALUE=DATE:20240101
+RRULE:FREQ=WEEKLY
+END:VEVENT
+BEGIN:VEVENT
+UID:zero-length
+SUMMARY:bob
+DTSTART;VALUE=DATE:20240102
+DURATION:PT0S
+END:VEVENT
+END:VCALENDAR
+`)
+
+	//A DATE DTSTART without DTEND or DURATION lasts one day
+	if want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC); !events[0].End.Equal(want) {
+		t.Errorf("got end %s, want %s", events[0].End, want)
+	}
+
+	if !events[1].End.Equal(events[1].Start) {
+		t.Errorf("got end %s, want the start %s", events[1].End, events[1].Start)
+	}
+
+	schedule := &ICalSchedule{Primary: events}
+	at := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	if got, want := schedule.OnCallAt(at), []string{"alice"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+const testWindowsTimezoneICal = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VTIMEZONE
+TZID:Pacific Standard Time
+BEGIN:STANDARD
+DTSTART:16010101T020000
+TZOFFSETFROM:-0700
+TZOFFSETTO:-0800
+RRULE:FREQ=YEARLY;INTERVAL=1;BYDAY=1SU;BYMONTH=11
+END:STANDARD
+BEGIN:DAYLIGHT
+DTSTART:16010101T020000
+TZOFFSETFROM:-0800
+TZOFFSETTO:-0700
+RRULE:FREQ=YEARLY;INTERVAL=1;BYMONTHDAY=8,9,10,11,12,13,14;BYDAY=SU;BYMONTH=3
+END:DAYLIGHT
+END:VTIMEZONE
+BEGIN:VEVENT
+UID:weekly
+SUMMARY:frank
+DTSTART;TZID=Pacific Standard Time:20240101T090000
+DTEND;TZID=Pacific Standard Time:20240101T170000
+RRULE:FREQ=WEEKLY
+EXDATE;TZID=Pacific Standard Time:20240715T090000
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestParseICalVTimezone(t *testing.T) {
+	events := mustParseICal(t, testWindowsTimezoneICal)
+	losAngeles := mustLoadLocation(t, "America/Los_Angeles")
+
+	loc := events[0].Start.Location()
+	for at := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC); at.Year() < 2030; at = at.Add(time.Hour) {
+		_, got := at.In(loc).Zone()
+		_, want := at.In(losAngeles).Zone()
+		if got != want {
+			t.Fatalf("offset at %s: got %d, want %d", at, got, want)
+		}
+	}
+
+	july := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	var starts []string
+	for _, event := range ExpandICalEvents(events, july, july.AddDate(0, 0, 21)) {
+		starts = append(starts, event.Start.UTC().Format("01-02 15:04"))
+	}
+
+	//The EXDATE on the 15th matches in daylight saving time
+	want := []string{"07-01 16:00", "07-08 16:00"}
+	if !reflect.DeepEqual(starts, want) {
+		t.Errorf("got %q, want %q", starts, want)
+	}
+}
+
+func TestParseICalUnsupportedVTimezone(t *testing.T) {
+	unsupported := strings.Replace(testWindowsTimezoneICal, "BYDAY=1SU", "BYDAY=5SU", 1)
+	if _, err := ParseICal(strings.NewReader(unsupported)); err == nil {
+		t.Fatal("expected an error for a rule a POSIX TZ rule can't express")
+	}
+
+	//Undefined timezones are only an error when used
+	unused := strings.Replace(unsupported, "TZID=Pacific Standard Time:", "TZID=UTC:", -1)
+	if _, err := ParseICal(strings.NewReader(unused)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestWriteICalRoundTrip(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	rule, err := parseICalRecurrence("FREQ=WEEKLY;BYDAY=MO", berlin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []ICalEvent{
+		{
+			UID:        "weekly",
+			Summary:    "grace",
+			Start:      time.Date(2024, 1, 1, 9, 0, 0, 0, berlin),
+			End:        time.Date(2024, 1, 1, 17, 0, 0, 0, berlin),
+			Recurrence: rule,
+			ExDates:    []time.Time{time.Date(2024, 4, 1, 9, 0, 0, 0, berlin)},
+		},
+		{
+			UID:     "single",
+			Summary: "heidi",
+			Start:   time.Date(2024, 7, 1, 9, 0, 0, 0, berlin),
+			End:     time.Date(2024, 7, 1, 17, 0, 0, 0, berlin),
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteICal(buf, events); err != nil {
+		t.Fatal(err)
+	}
+
+	//Rename the zone so that the written VTIMEZONE has to be used
+	written := strings.ReplaceAll(buf.String(), "Europe/Berlin", "Custom Berlin")
+	for _, line := range []string{
+		"TZID:Custom Berlin\r\n",
+		"DTSTART;TZID=Custom Berlin:20240101T090000\r\n",
+		"DTSTART:20240701T070000Z\r\n",
+	} {
+		if !strings.Contains(written, line) {
+			t.Errorf("expected %q in:\n%s", line, written)
+		}
+	}
+
+	parsed := mustParseICal(t, written)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	got := ExpandICalEvents(parsed, start, end)
+	want := ExpandICalEvents(events, start, end)
+	if len(got) != len(want) {
+		t.Fatalf("got %d occurrences, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Fatalf("occurrence %d: got %s-%s, want %s-%s",
+				i, got[i].Start, got[i].End, want[i].Start, want[i].End)
+		}
+	}
+}
@@ -0,0 +1,463 @@
+package oncall
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// icalZones holds the locations defined by the VTIMEZONEs of an iCalendar.
+type icalZones map[string]icalZone
+
+// icalZone is the location built from a VTIMEZONE, or the reason it could not
+// be built. The error is only returned if the TZID is used.
+type icalZone struct {
+	loc *time.Location
+	err error
+}
+
+// location returns the location of the TZID, preferring the tz database.
+func (z icalZones) location(tzid string) (*time.Location, error) {
+	tzid = strings.TrimPrefix(tzid, "/")
+	loc, err := time.LoadLocation(tzid)
+	if err == nil {
+		return loc, nil
+	}
+
+	if zone, found := z[tzid]; found {
+		if zone.err != nil {
+			return nil, fmt.Errorf("VTIMEZONE %q: %w", tzid, zone.err)
+		}
+
+		return zone.loc, nil
+	}
+
+	return nil, err
+}
+
+// icalObservance is a STANDARD or DAYLIGHT component of a VTIMEZONE.
+type icalObservance struct {
+	daylight bool
+	name     string
+	//start is the local time of the first onset, given in UTC
+	start      time.Time
+	offsetFrom int
+	offsetTo   int
+	rule       *ICalRecurrence
+}
+
+// parseICalTimezones builds a location from every VTIMEZONE whose TZID is not
+// in the tz database.
+func parseICalTimezones(props []icalProperty) icalZones {
+	ret := icalZones{}
+	var tzid string
+	var inTimezone bool
+	var observances []icalObservance
+	var cur *icalObservance
+	var rrule string
+	//zoneErr is the first error in the current VTIMEZONE
+	var zoneErr error
+	for _, prop := range props {
+		var err error
+		switch {
+		case prop.Name == "BEGIN" && strings.EqualFold(prop.Value, "VTIMEZONE"):
+			inTimezone = true
+			tzid = ""
+			observances = nil
+			zoneErr = nil
+		case !inTimezone:
+		case prop.Name == "END" && strings.EqualFold(prop.Value, "VTIMEZONE"):
+			inTimezone = false
+			if _, tzErr := time.LoadLocation(tzid); tzid == "" || tzErr == nil {
+				continue
+			}
+
+			zone := icalZone{err: zoneErr}
+			if zone.err == nil {
+				zone.loc, zone.err = icalTimezoneLocation(tzid, observances)
+			}
+			ret[tzid] = zone
+		case prop.Name == "TZID":
+			tzid = strings.TrimPrefix(prop.Value, "/")
+		case prop.Name == "BEGIN" &&
+			(strings.EqualFold(prop.Value, "STANDARD") || strings.EqualFold(prop.Value, "DAYLIGHT")):
+			cur = &icalObservance{daylight: strings.EqualFold(prop.Value, "DAYLIGHT")}
+			rrule = ""
+		case cur == nil:
+		case prop.Name == "END":
+			if rrule != "" {
+				cur.rule, err = parseICalRecurrence(rrule, time.UTC)
+			}
+			observances = append(observances, *cur)
+			cur = nil
+		case prop.Name == "DTSTART":
+			cur.start, err = parseICalTimeValue(prop.Value, prop.Params["VALUE"], time.UTC)
+		case prop.Name == "TZOFFSETFROM":
+			cur.offsetFrom, err = parseICalUTCOffset(prop.Value)
+		case prop.Name == "TZOFFSETTO":
+			cur.offsetTo, err = parseICalUTCOffset(prop.Value)
+		case prop.Name == "TZNAME":
+			cur.name = prop.Value
+		case prop.Name == "RRULE":
+			rrule = prop.Value
+		}
+		if err != nil && zoneErr == nil {
+			zoneErr = fmt.Errorf("%s: %w", prop.Name, err)
+		}
+	}
+
+	return ret
+}
+
+// icalTimezoneLocation builds a location from the latest STANDARD and DAYLIGHT
+// observances of a VTIMEZONE, which are applied to all times. If both recur,
+// they are compiled into a POSIX TZ rule. Otherwise, the offset of the latest
+// observance is used. Rules which a POSIX TZ rule can't express are rejected
+// rather than approximated.
+func icalTimezoneLocation(tzid string, observances []icalObservance) (*time.Location, error) {
+	var latest, standard, daylight *icalObservance
+	for i := range observances {
+		o := &observances[i]
+		if latest == nil || o.start.After(latest.start) {
+			latest = o
+		}
+
+		if o.daylight && (daylight == nil || o.start.After(daylight.start)) {
+			daylight = o
+		}
+
+		if !o.daylight && (standard == nil || o.start.After(standard.start)) {
+			standard = o
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("no STANDARD or DAYLIGHT observances")
+	}
+
+	if standard == nil || daylight == nil || standard.rule == nil || daylight.rule == nil {
+		return time.FixedZone(tzid, latest.offsetTo), nil
+	}
+
+	daylightStart, err := posixTransitionRule(daylight)
+	if err != nil {
+		return nil, fmt.Errorf("DAYLIGHT: %w", err)
+	}
+
+	daylightEnd, err := posixTransitionRule(standard)
+	if err != nil {
+		return nil, fmt.Errorf("STANDARD: %w", err)
+	}
+
+	standardName := posixZoneName(standard)
+	rule := fmt.Sprintf(
+		"<%s>%s<%s>%s,%s,%s",
+		standardName,
+		posixOffset(standard.offsetTo),
+		posixZoneName(daylight),
+		posixOffset(daylight.offsetTo),
+		daylightStart,
+		daylightEnd,
+	)
+
+	return time.LoadLocationFromTZData(tzid, tzifWithRule(standard.offsetTo, standardName, rule))
+}
+
+// posixTransitionRule converts the yearly RRULE of an observance into the
+// "Mm.w.d/time" form of a POSIX TZ rule. The time is the local time before
+// the transition, in both formats.
+func posixTransitionRule(o *icalObservance) (string, error) {
+	r := o.rule
+	if r.Frequency != ICalFrequencyYearly || len(r.ByMonth) != 1 || len(r.ByDay) != 1 {
+		return "", fmt.Errorf("unsupported RRULE %q", r.String())
+	}
+
+	week := r.ByDay[0].N
+	if week == 0 {
+		//Some calendars select the Nth weekday with seven consecutive month
+		//days, like BYMONTHDAY=8,9,10,11,12,13,14;BYDAY=SU
+		monthDays := append([]int{}, r.ByMonthDay...)
+		sort.Ints(monthDays)
+		if len(monthDays) != 7 || monthDays[0] < 1 || (monthDays[0]-1)%7 != 0 ||
+			monthDays[6] != monthDays[0]+6 {
+			return "", fmt.Errorf("unsupported RRULE %q", r.String())
+		}
+
+		week = (monthDays[0]-1)/7 + 1
+	} else if len(r.ByMonthDay) > 0 {
+		return "", fmt.Errorf("unsupported RRULE %q", r.String())
+	}
+
+	switch {
+	case week == -1:
+		//POSIX uses the fifth week to mean the last
+		week = 5
+	case week < 1 || week > 4:
+		return "", fmt.Errorf("unsupported RRULE %q", r.String())
+	}
+
+	return fmt.Sprintf(
+		"M%d.%d.%d/%d:%02d:%02d",
+		r.ByMonth[0],
+		week,
+		r.ByDay[0].Weekday,
+		o.start.Hour(),
+		o.start.Minute(),
+		o.start.Second(),
+	), nil
+}
+
+// posixZoneName returns the TZNAME of the observance, or its offset if it has
+// none, with the characters a quoted POSIX TZ name can't hold removed.
+func posixZoneName(o *icalObservance) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '+' || r == '-' {
+			return r
+		}
+
+		return -1
+	}, o.name)
+
+	if name == "" {
+		return formatICalUTCOffset(o.offsetTo)
+	}
+
+	return name
+}
+
+// posixOffset formats seconds east of UTC as a POSIX TZ offset, which counts
+// hours west of UTC.
+func posixOffset(offset int) string {
+	sign := ""
+	if offset > 0 {
+		sign = "-"
+	} else {
+		offset = -offset
+	}
+
+	return fmt.Sprintf("%s%d:%02d:%02d", sign, offset/3600, offset/60%60, offset%60)
+}
+
+// tzifWithRule builds TZif data with no transitions, so that the POSIX TZ rule
+// in its footer applies to all times.
+func tzifWithRule(offset int, name string, rule string) []byte {
+	b := &bytes.Buffer{}
+	//Version 1 data is followed by the same data in the version 2 format,
+	//which is the same for a file without transitions
+	for i := 0; i < 2; i++ {
+		b.WriteString("TZif2")
+		b.Write(make([]byte, 15))
+		//isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt
+		for _, count := range []uint32{0, 0, 0, 0, 1, uint32(len(name) + 1)} {
+			_ = binary.Write(b, binary.BigEndian, count)
+		}
+
+		_ = binary.Write(b, binary.BigEndian, int32(offset))
+		b.WriteByte(0) //isdst
+		b.WriteByte(0) //abbreviation index
+		b.WriteString(name)
+		b.WriteByte(0)
+	}
+
+	b.WriteString("\n" + rule + "\n")
+	return b.Bytes()
+}
+
+// parseICalUTCOffset parses a UTC-OFFSET value, like "+0100" or "-053000",
+// into seconds east of UTC.
+func parseICalUTCOffset(s string) (int, error) {
+	if len(s) != 5 && len(s) != 7 || (s[0] != '+' && s[0] != '-') {
+		return 0, fmt.Errorf("malformed UTC offset %q", s)
+	}
+
+	var parts [3]int
+	for i := 0; i*2+1 < len(s); i++ {
+		for _, r := range s[i*2+1 : i*2+3] {
+			if r < '0' || r > '9' {
+				return 0, fmt.Errorf("malformed UTC offset %q", s)
+			}
+			parts[i] = parts[i]*10 + int(r-'0')
+		}
+	}
+
+	ret := parts[0]*3600 + parts[1]*60 + parts[2]
+	if s[0] == '-' {
+		ret = -ret
+	}
+
+	return ret, nil
+}
+
+// formatICalUTCOffset formats seconds east of UTC as a UTC-OFFSET value.
+func formatICalUTCOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+
+	ret := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset/60%60)
+	if offset%60 != 0 {
+		ret += fmt.Sprintf("%02d", offset%60)
+	}
+
+	return ret
+}
+
+// icalEventZone returns the TZID and location that the times of a recurring
+// event are written in. The location is nil if the event is written in UTC.
+func icalEventZone(event ICalEvent) (string, *time.Location) {
+	loc := event.Start.Location()
+	if event.Recurrence == nil || loc == time.UTC {
+		return "", nil
+	}
+
+	tzid := loc.String()
+	switch tzid {
+	case "", "UTC":
+		_, offset := event.Start.Zone()
+		tzid = "UTC" + formatICalUTCOffset(offset)
+	case "Local":
+		//"Local" would be read as the local time of the reader
+		tzid = "Local Time"
+	}
+
+	return tzid, loc
+}
+
+// icalZoneRange is a location and the range of times written in it.
+type icalZoneRange struct {
+	loc  *time.Location
+	from time.Time
+	to   time.Time
+}
+
+func (z *icalZoneRange) include(times ...time.Time) {
+	for _, t := range times {
+		if t.IsZero() {
+			continue
+		}
+
+		if t.Before(z.from) {
+			z.from = t
+		}
+
+		if t.After(z.to) {
+			z.to = t
+		}
+	}
+}
+
+// writeICalTimezone writes a VTIMEZONE with every transition of the location
+// from the start of the year of zone.from to the end of the year after
+// zone.to. If the location still observes daylight saving time in that last
+// year, its last transitions are repeated yearly.
+func writeICalTimezone(iw *icalWriter, tzid string, zone *icalZoneRange) {
+	loc := zone.loc
+	begin := time.Date(zone.from.In(loc).Year(), 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(zone.to.In(loc).Year()+2, 1, 1, 0, 0, 0, 0, loc)
+
+	name, offset := begin.Zone()
+	observances := []icalObservance{{
+		daylight:   begin.IsDST(),
+		name:       name,
+		start:      time.Unix(begin.Unix()+int64(offset), 0).UTC(),
+		offsetFrom: offset,
+		offsetTo:   offset,
+	}}
+
+	lastStandard, lastDaylight := -1, -1
+	for t := begin; t.Before(end); {
+		next := t.Add(24 * time.Hour)
+		if icalSameZone(t, next) {
+			t = next
+			continue
+		}
+
+		//Find the first second in the zone of next
+		lo, hi := t.Unix(), next.Unix()
+		for hi-lo > 1 {
+			mid := lo + (hi-lo)/2
+			if icalSameZone(t, time.Unix(mid, 0).In(loc)) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+
+		_, offsetFrom := t.Zone()
+		t = time.Unix(hi, 0).In(loc)
+		name, offsetTo := t.Zone()
+		observances = append(observances, icalObservance{
+			daylight:   t.IsDST(),
+			name:       name,
+			start:      time.Unix(hi+int64(offsetFrom), 0).UTC(),
+			offsetFrom: offsetFrom,
+			offsetTo:   offsetTo,
+		})
+
+		if t.IsDST() {
+			lastDaylight = len(observances) - 1
+		} else {
+			lastStandard = len(observances) - 1
+		}
+	}
+
+	lastYear := end.Year() - 1
+	if lastStandard > 0 && lastDaylight > 0 &&
+		observances[lastStandard].start.Year() == lastYear &&
+		observances[lastDaylight].start.Year() == lastYear {
+
+		observances[lastStandard].rule = icalYearlyRule(observances[lastStandard].start)
+		observances[lastDaylight].rule = icalYearlyRule(observances[lastDaylight].start)
+	}
+
+	iw.line("BEGIN:VTIMEZONE")
+	iw.line("TZID:" + tzid)
+	for _, o := range observances {
+		component := "STANDARD"
+		if o.daylight {
+			component = "DAYLIGHT"
+		}
+
+		iw.line("BEGIN:" + component)
+		iw.line("DTSTART:" + o.start.Format(icalDateTimeLayout))
+		iw.line("TZOFFSETFROM:" + formatICalUTCOffset(o.offsetFrom))
+		iw.line("TZOFFSETTO:" + formatICalUTCOffset(o.offsetTo))
+		if o.name != "" {
+			iw.line("TZNAME:" + icalTextEscaper.Replace(o.name))
+		}
+		if o.rule != nil {
+			iw.line("RRULE:" + o.rule.String())
+		}
+		iw.line("END:" + component)
+	}
+	iw.line("END:VTIMEZONE")
+}
+
+func icalSameZone(a, b time.Time) bool {
+	aName, aOffset := a.Zone()
+	bName, bOffset := b.Zone()
+	return aName == bName && aOffset == bOffset && a.IsDST() == b.IsDST()
+}
+
+// icalYearlyRule returns a rule repeating a transition on the same weekday of
+// the month every year. Transitions in the last week of the month are taken
+// to be on the last such weekday.
+func icalYearlyRule(local time.Time) *ICalRecurrence {
+	daysInMonth := time.Date(local.Year(), local.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	week := (local.Day()-1)/7 + 1
+	if local.Day()+7 > daysInMonth {
+		week = -1
+	}
+
+	return &ICalRecurrence{
+		Frequency: ICalFrequencyYearly,
+		ByMonth:   []time.Month{local.Month()},
+		ByDay:     []ICalWeekday{{Weekday: local.Weekday(), N: week}},
+		WeekStart: time.Monday,
+	}
+}
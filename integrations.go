@@ -0,0 +1,443 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const integrationPath = "integrations"
+
+type Integration struct {
+	ID          string
+	Name        string
+	Description string
+	Type        IntegrationType
+	TeamID      string
+	//Link is the inbound URL which alerts are sent to
+	Link         string
+	InboundEmail string
+	DefaultRoute IntegrationDefaultRoute
+	Templates    IntegrationTemplates
+	//Heartbeat is nil if the integration does not have a heartbeat
+	Heartbeat *IntegrationHeartbeat
+	//MaintenanceMode is empty if the integration is not in maintenance
+	MaintenanceMode      IntegrationMaintenanceMode
+	MaintenanceStartedAt time.Time
+	MaintenanceEndAt     time.Time
+}
+
+type integrationRaw struct {
+	ID           string                  `json:"id,omitempty"`
+	Name         string                  `json:"name"`
+	Description  string                  `json:"description_short,omitempty"`
+	Type         string                  `json:"type"`
+	TeamID       string                  `json:"team_id,omitempty"`
+	Link         string                  `json:"link,omitempty"`
+	InboundEmail string                  `json:"inbound_email,omitempty"`
+	DefaultRoute IntegrationDefaultRoute `json:"default_route"`
+	Templates    IntegrationTemplates    `json:"templates"`
+	Heartbeat    *IntegrationHeartbeat   `json:"heartbeat,omitempty"`
+
+	MaintenanceMode      IntegrationMaintenanceMode `json:"maintenance_mode,omitempty"`
+	MaintenanceStartedAt string                     `json:"maintenance_started_at,omitempty"`
+	MaintenanceEndAt     string                     `json:"maintenance_end_at,omitempty"`
+}
+
+func (i *Integration) MarshalJSON() ([]byte, error) {
+	if i == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(&integrationRaw{
+		ID:           i.ID,
+		Name:         i.Name,
+		Description:  i.Description,
+		Type:         i.Type.String(),
+		TeamID:       i.TeamID,
+		Link:         i.Link,
+		InboundEmail: i.InboundEmail,
+		DefaultRoute: i.DefaultRoute,
+		Templates:    i.Templates,
+		Heartbeat:    i.Heartbeat,
+
+		MaintenanceMode:      i.MaintenanceMode,
+		MaintenanceStartedAt: optionalTimeToString(i.MaintenanceStartedAt),
+		MaintenanceEndAt:     optionalTimeToString(i.MaintenanceEndAt),
+	})
+}
+
+func (i *Integration) UnmarshalJSON(b []byte) error {
+	raw := integrationRaw{}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	*i = Integration{
+		ID:           raw.ID,
+		Name:         raw.Name,
+		Description:  raw.Description,
+		Type:         integrationTypeFromString(raw.Type),
+		TeamID:       raw.TeamID,
+		Link:         raw.Link,
+		InboundEmail: raw.InboundEmail,
+		DefaultRoute: raw.DefaultRoute,
+		Templates:    raw.Templates,
+		Heartbeat:    raw.Heartbeat,
+
+		MaintenanceMode: raw.MaintenanceMode,
+	}
+
+	i.MaintenanceStartedAt, err = optionalTimeFromString(raw.MaintenanceStartedAt)
+	if err != nil {
+		return err
+	}
+
+	i.MaintenanceEndAt, err = optionalTimeFromString(raw.MaintenanceEndAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IntegrationHeartbeat is a dead man's switch on an integration. If the Link
+// is not requested within the heartbeat timeout, OnCall raises an alert. The
+// timeout is not exposed by the public API, and is set in the OnCall UI. A
+// Heartbeat can be used to request the Link periodically.
+type IntegrationHeartbeat struct {
+	Link string `json:"link"`
+}
+
+// IntegrationDefaultRoute is the route alerts take when they match no other
+// route of the integration.
+type IntegrationDefaultRoute struct {
+	ID                string                `json:"id,omitempty"`
+	EscalationChainID string                `json:"escalation_chain_id,omitempty"`
+	Slack             RouteSlackSettings    `json:"slack"`
+	Telegram          RouteTelegramSettings `json:"telegram"`
+}
+
+// IntegrationTemplates are the Jinja2 templates used to process alerts received
+// by an integration. Empty templates are left unchanged when sent to the API.
+type IntegrationTemplates struct {
+	GroupingKey       string                     `json:"grouping_key,omitempty"`
+	ResolveSignal     string                     `json:"resolve_signal,omitempty"`
+	AcknowledgeSignal string                     `json:"acknowledge_signal,omitempty"`
+	SourceLink        string                     `json:"source_link,omitempty"`
+	Slack             IntegrationMessageTemplate `json:"slack"`
+	Web               IntegrationMessageTemplate `json:"web"`
+	Telegram          IntegrationMessageTemplate `json:"telegram"`
+	Email             IntegrationMessageTemplate `json:"email"`
+	SMS               IntegrationMessageTemplate `json:"sms"`
+	PhoneCall         IntegrationMessageTemplate `json:"phone_call"`
+}
+
+// IntegrationMessageTemplate holds the templates for a single notification
+// channel. Not every channel uses every template.
+type IntegrationMessageTemplate struct {
+	Title    string `json:"title,omitempty"`
+	Message  string `json:"message,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+}
+
+// IntegrationMaintenanceMode is how an integration handles alerts while in
+// maintenance. In maintenance mode, alerts are collected into a single alert
+// group without notifying anyone. In debug mode, alerts are processed as usual
+// but nobody is notified.
+type IntegrationMaintenanceMode string
+
+const (
+	IntegrationMaintenanceModeMaintenance IntegrationMaintenanceMode = "maintenance"
+	IntegrationMaintenanceModeDebug       IntegrationMaintenanceMode = "debug"
+)
+
+type IntegrationType int
+
+func (i IntegrationType) String() string {
+	if i < 0 || i >= integrationTypeLen {
+		return "unknown"
+	}
+
+	return integrationTypeStringLookup[i]
+}
+
+const (
+	IntegrationTypeUnknown IntegrationType = iota
+	IntegrationTypeGrafana
+	IntegrationTypeGrafanaAlerting
+	IntegrationTypeAlertmanager
+	IntegrationTypeWebhook
+	IntegrationTypeFormattedWebhook
+	IntegrationTypeHeartbeat
+	IntegrationTypeInboundEmail
+	IntegrationTypeManual
+	IntegrationTypeDirectPaging
+	IntegrationTypeKapacitor
+	IntegrationTypeElastAlert
+	IntegrationTypeAmazonSNS
+	IntegrationTypeStackdriver
+	IntegrationTypeCurler
+	IntegrationTypeDatadog
+	IntegrationTypeNewRelic
+	IntegrationTypePagerDuty
+	IntegrationTypePingdom
+	IntegrationTypeSentry
+	IntegrationTypeUptimeRobot
+	IntegrationTypeZabbix
+	IntegrationTypePRTG
+	IntegrationTypeFabric
+	IntegrationTypeJira
+	IntegrationTypeZendesk
+	integrationTypeLen
+)
+
+var integrationTypeStringLookup = [integrationTypeLen]string{
+	"unknown",
+	"grafana",
+	"grafana_alerting",
+	"alertmanager",
+	"webhook",
+	"formatted_webhook",
+	"heartbeat",
+	"inbound_email",
+	"manual",
+	"direct_paging",
+	"kapacitor",
+	"elastalert",
+	"amazon_sns",
+	"stackdriver",
+	"curler",
+	"datadog",
+	"newrelic",
+	"pagerduty",
+	"pingdom",
+	"sentry",
+	"uptimerobot",
+	"zabbix",
+	"prtg",
+	"fabric",
+	"jira",
+	"zendesk",
+}
+
+func integrationTypeFromString(s string) IntegrationType {
+	s = strings.ToLower(s)
+	for i, str := range integrationTypeStringLookup {
+		if str == s {
+			return IntegrationType(i)
+		}
+	}
+
+	return IntegrationTypeUnknown
+}
+
+type IntegrationFilter struct {
+	Name   string
+	TeamID string
+}
+
+func (f *IntegrationFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.Name != "" {
+			values.Set("name", f.Name)
+		}
+
+		if f.TeamID != "" {
+			values.Set("team_id", f.TeamID)
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListIntegrationsByPage(
+	page int,
+	filter *IntegrationFilter,
+) (*PaginatedResponse[Integration], error) {
+	return c.ListIntegrationsByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListIntegrationsByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *IntegrationFilter,
+) (*PaginatedResponse[Integration], error) {
+	return getPage[Integration](ctx, c, page, integrationPath, filter.values())
+}
+
+func (c *Client) ListIntegrations(filter *IntegrationFilter) ([]Integration, error) {
+	return c.ListIntegrationsCtx(context.Background(), filter)
+}
+
+func (c *Client) ListIntegrationsCtx(
+	ctx context.Context,
+	filter *IntegrationFilter,
+) ([]Integration, error) {
+	return paginate(c.IntegrationsIterCtx(ctx, filter))
+}
+
+func (c *Client) IntegrationsIter(filter *IntegrationFilter) *Iterator[Integration] {
+	return c.IntegrationsIterCtx(context.Background(), filter)
+}
+
+func (c *Client) IntegrationsIterCtx(
+	ctx context.Context,
+	filter *IntegrationFilter,
+) *Iterator[Integration] {
+	return newIterator[Integration](ctx, c, integrationPath, filter.values())
+}
+
+func (c *Client) GetIntegration(id string) (*Integration, error) {
+	return c.GetIntegrationCtx(context.Background(), id)
+}
+
+func (c *Client) GetIntegrationCtx(ctx context.Context, id string) (*Integration, error) {
+	ret := &Integration{}
+	err := c.doRequest(ctx, "GET", buildPath(integrationPath, id), nil, ret)
+	return ret, err
+}
+
+type CreateIntegrationOptions struct {
+	TeamID       string
+	Description  string
+	DefaultRoute *IntegrationDefaultRoute
+	Templates    *IntegrationTemplates
+}
+
+func (c *Client) CreateIntegration(
+	name string,
+	integrationType IntegrationType,
+	opts *CreateIntegrationOptions,
+) (*Integration, error) {
+	return c.CreateIntegrationCtx(context.Background(), name, integrationType, opts)
+}
+
+func (c *Client) CreateIntegrationCtx(
+	ctx context.Context,
+	name string,
+	integrationType IntegrationType,
+	opts *CreateIntegrationOptions,
+) (*Integration, error) {
+
+	requestBody := struct {
+		Name         string                   `json:"name"`
+		Type         string                   `json:"type"`
+		TeamID       string                   `json:"team_id,omitempty"`
+		Description  string                   `json:"description_short,omitempty"`
+		DefaultRoute *IntegrationDefaultRoute `json:"default_route,omitempty"`
+		Templates    *IntegrationTemplates    `json:"templates,omitempty"`
+	}{
+		Name: name,
+		Type: integrationType.String(),
+	}
+
+	if opts != nil {
+		requestBody.TeamID = opts.TeamID
+		requestBody.Description = opts.Description
+		requestBody.DefaultRoute = opts.DefaultRoute
+		requestBody.Templates = opts.Templates
+	}
+
+	ret := &Integration{}
+	err := c.doRequest(ctx, "POST", integrationPath, &requestBody, ret)
+	return ret, err
+}
+
+// UpdateIntegrationOptions holds the fields to change on an integration. Only
+// non-nil fields are sent.
+type UpdateIntegrationOptions struct {
+	Name         *string
+	Description  *string
+	DefaultRoute *IntegrationDefaultRoute
+	Templates    *IntegrationTemplates
+}
+
+func (c *Client) UpdateIntegration(
+	id string,
+	opts *UpdateIntegrationOptions,
+) (*Integration, error) {
+	return c.UpdateIntegrationCtx(context.Background(), id, opts)
+}
+
+func (c *Client) UpdateIntegrationCtx(
+	ctx context.Context,
+	id string,
+	opts *UpdateIntegrationOptions,
+) (*Integration, error) {
+
+	requestBody := struct {
+		Name         *string                  `json:"name,omitempty"`
+		Description  *string                  `json:"description_short,omitempty"`
+		DefaultRoute *IntegrationDefaultRoute `json:"default_route,omitempty"`
+		Templates    *IntegrationTemplates    `json:"templates,omitempty"`
+	}{}
+
+	if opts != nil {
+		requestBody.Name = opts.Name
+		requestBody.Description = opts.Description
+		requestBody.DefaultRoute = opts.DefaultRoute
+		requestBody.Templates = opts.Templates
+	}
+
+	ret := &Integration{}
+	err := c.doRequest(ctx, "PUT", buildPath(integrationPath, id), &requestBody, ret)
+	return ret, err
+}
+
+func (c *Client) DeleteIntegration(id string) error {
+	return c.DeleteIntegrationCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteIntegrationCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(integrationPath, id), nil, nil)
+}
+
+// StartIntegrationMaintenance puts the integration into the given maintenance
+// mode for the duration, which is sent with a precision of seconds. The API
+// only accepts durations of 1, 3, 6, 12, or 24 hours.
+func (c *Client) StartIntegrationMaintenance(
+	id string,
+	mode IntegrationMaintenanceMode,
+	duration time.Duration,
+) error {
+	return c.StartIntegrationMaintenanceCtx(context.Background(), id, mode, duration)
+}
+
+func (c *Client) StartIntegrationMaintenanceCtx(
+	ctx context.Context,
+	id string,
+	mode IntegrationMaintenanceMode,
+	duration time.Duration,
+) error {
+
+	requestBody := struct {
+		Mode     IntegrationMaintenanceMode `json:"mode"`
+		Duration int64                      `json:"duration"`
+	}{
+		Mode:     mode,
+		Duration: int64(duration.Seconds()),
+	}
+
+	return c.doRequest(
+		ctx,
+		"POST",
+		buildPath(integrationPath, id, "maintenance_start"),
+		&requestBody,
+		nil,
+	)
+}
+
+// StopIntegrationMaintenance takes the integration out of maintenance or debug
+// mode before its maintenance ends.
+func (c *Client) StopIntegrationMaintenance(id string) error {
+	return c.StopIntegrationMaintenanceCtx(context.Background(), id)
+}
+
+func (c *Client) StopIntegrationMaintenanceCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "POST", buildPath(integrationPath, id, "maintenance_stop"), nil, nil)
+}
@@ -0,0 +1,107 @@
+package oncall
+
+import (
+	"context"
+	"net/url"
+)
+
+// Iterator lazily walks every item of a paginated listing. Pages are fetched
+// as Next is called, so stopping early does not fetch the rest of the listing.
+// Each page after the first is requested with the query of the server-provided
+// Next link.
+//
+//	it := client.AlertsIter(nil)
+//	for it.Next() {
+//		alert := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx    context.Context
+	client *Client
+	path   string
+	query  url.Values
+
+	page []T
+	cur  T
+	done bool
+	err  error
+}
+
+func newIterator[T any](
+	ctx context.Context,
+	c *Client,
+	path string,
+	query url.Values,
+) *Iterator[T] {
+
+	query.Set("page", "1")
+	return &Iterator[T]{
+		ctx:    ctx,
+		client: c,
+		path:   path,
+		query:  query,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// necessary. It returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		it.err = it.fetch()
+	}
+
+	it.cur = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Value returns the item the iterator is currently on.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+func (it *Iterator[T]) fetch() error {
+	err := it.ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	resp := &PaginatedResponse[T]{}
+	err = it.client.doRequest(it.ctx, "GET", it.path, it.query, resp)
+	if err != nil {
+		return err
+	}
+
+	it.page = resp.Results
+	if resp.Next == "" {
+		it.done = true
+		return nil
+	}
+
+	next, err := url.Parse(resp.Next)
+	if err != nil {
+		return err
+	}
+
+	nextQuery := next.Query()
+	if nextQuery.Encode() == it.query.Encode() {
+		//Don't request the same page forever if the server links back to it
+		it.done = true
+		return nil
+	}
+
+	it.query = nextQuery
+	return nil
+}
@@ -0,0 +1,517 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const onCallShiftPath = "on_call_shifts"
+
+// onCallShiftTimeLayout is a wall-clock time, interpreted in the time zone of
+// the shift.
+const onCallShiftTimeLayout = "2006-01-02T15:04:05"
+
+type OnCallShift struct {
+	ID     string
+	TeamID string
+	Name   string
+	//TimeZone is the zone Start and Recurrence.Until are given in. If nil, UTC
+	//is used.
+	TimeZone *time.Location
+	//Level is the priority of the shift. Higher levels override lower levels.
+	Level    int
+	Start    time.Time
+	Duration time.Duration
+	//Recurrence is nil for shifts which do not repeat
+	Recurrence *OnCallShiftRecurrence
+	Variant    OnCallShiftVariant
+}
+
+type onCallShiftRawHeaders struct {
+	ID         string   `json:"id"`
+	TeamID     string   `json:"team_id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	TimeZone   string   `json:"time_zone"`
+	Level      int      `json:"level"`
+	Start      string   `json:"start"`
+	Duration   int64    `json:"duration"`
+	Frequency  string   `json:"frequency"`
+	Interval   int      `json:"interval"`
+	WeekStart  string   `json:"week_start"`
+	ByDay      []string `json:"by_day"`
+	ByMonth    []int    `json:"by_month"`
+	ByMonthday []int    `json:"by_monthday"`
+	Until      string   `json:"until"`
+}
+
+func (o *OnCallShift) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("null"), nil
+	}
+
+	out := map[string]interface{}{}
+	if o.Variant != nil {
+		inter, err := json.Marshal(&o.Variant)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(inter, &out)
+		if err != nil {
+			return nil, err
+		}
+
+		out["type"] = o.Variant.OnCallShiftType().String()
+	}
+
+	loc := o.TimeZone
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if o.ID != "" {
+		out["id"] = o.ID
+	}
+	out["name"] = o.Name
+	if o.TeamID != "" {
+		out["team_id"] = o.TeamID
+	}
+	if o.TimeZone != nil {
+		out["time_zone"] = o.TimeZone.String()
+	}
+	out["level"] = o.Level
+	out["start"] = o.Start.In(loc).Format(onCallShiftTimeLayout)
+	out["duration"] = int64(o.Duration.Seconds())
+	if o.Recurrence != nil {
+		o.Recurrence.addFields(out, loc)
+	}
+
+	return json.Marshal(&out)
+}
+
+func (o *OnCallShift) UnmarshalJSON(b []byte) error {
+	rawHeaders := onCallShiftRawHeaders{}
+	err := json.Unmarshal(b, &rawHeaders)
+	if err != nil {
+		return err
+	}
+
+	*o = OnCallShift{
+		ID:       rawHeaders.ID,
+		TeamID:   rawHeaders.TeamID,
+		Name:     rawHeaders.Name,
+		Level:    rawHeaders.Level,
+		Duration: time.Duration(rawHeaders.Duration) * time.Second,
+	}
+
+	loc := time.UTC
+	if rawHeaders.TimeZone != "" {
+		o.TimeZone, err = time.LoadLocation(rawHeaders.TimeZone)
+		if err != nil {
+			return err
+		}
+
+		loc = o.TimeZone
+	}
+
+	o.Start, err = time.ParseInLocation(onCallShiftTimeLayout, rawHeaders.Start, loc)
+	if err != nil {
+		return err
+	}
+
+	if rawHeaders.Frequency != "" {
+		o.Recurrence = &OnCallShiftRecurrence{
+			Frequency:  OnCallShiftFrequency(rawHeaders.Frequency),
+			Interval:   rawHeaders.Interval,
+			WeekStart:  weekdayFromString(rawHeaders.WeekStart),
+			ByMonth:    rawHeaders.ByMonth,
+			ByMonthday: rawHeaders.ByMonthday,
+		}
+
+		for _, day := range rawHeaders.ByDay {
+			o.Recurrence.ByDay = append(o.Recurrence.ByDay, weekdayFromString(day))
+		}
+
+		if rawHeaders.Until != "" {
+			o.Recurrence.Until, err = time.ParseInLocation(
+				onCallShiftTimeLayout,
+				rawHeaders.Until,
+				loc,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	variant := onCallShiftVariantFromString(rawHeaders.Type)
+	if variant != nil {
+		err = json.Unmarshal(b, variant)
+		if err != nil {
+			return err
+		}
+	}
+
+	o.Variant = variant
+	return nil
+}
+
+// OnCallShiftRecurrence describes how a shift repeats, in the manner of an
+// RFC 5545 RRULE.
+type OnCallShiftRecurrence struct {
+	Frequency OnCallShiftFrequency
+	//Interval is the number of Frequency units between repetitions. Values
+	//less than 1 are treated as 1.
+	Interval int
+	//WeekStart is the day weeks start on for weekly recurrences. Note that the
+	//zero value is time.Sunday.
+	WeekStart  time.Weekday
+	ByDay      []time.Weekday
+	ByMonth    []int
+	ByMonthday []int
+	//Until is the zero time if the shift repeats forever
+	Until time.Time
+}
+
+func (r *OnCallShiftRecurrence) addFields(out map[string]interface{}, loc *time.Location) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	out["frequency"] = r.Frequency
+	out["interval"] = interval
+	out["week_start"] = weekdayToString(r.WeekStart)
+	if r.ByDay != nil {
+		byDay := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			byDay[i] = weekdayToString(day)
+		}
+		out["by_day"] = byDay
+	}
+	if r.ByMonth != nil {
+		out["by_month"] = r.ByMonth
+	}
+	if r.ByMonthday != nil {
+		out["by_monthday"] = r.ByMonthday
+	}
+	if !r.Until.IsZero() {
+		out["until"] = r.Until.In(loc).Format(onCallShiftTimeLayout)
+	}
+}
+
+type OnCallShiftFrequency string
+
+const (
+	OnCallShiftFrequencyHourly  OnCallShiftFrequency = "hourly"
+	OnCallShiftFrequencyDaily   OnCallShiftFrequency = "daily"
+	OnCallShiftFrequencyWeekly  OnCallShiftFrequency = "weekly"
+	OnCallShiftFrequencyMonthly OnCallShiftFrequency = "monthly"
+)
+
+var weekdayStringLookup = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func weekdayToString(d time.Weekday) string {
+	if d < 0 || int(d) >= len(weekdayStringLookup) {
+		return weekdayStringLookup[time.Monday]
+	}
+
+	return weekdayStringLookup[d]
+}
+
+func weekdayFromString(s string) time.Weekday {
+	s = strings.ToUpper(s)
+	for i, str := range weekdayStringLookup {
+		if str == s {
+			return time.Weekday(i)
+		}
+	}
+
+	return time.Monday
+}
+
+type OnCallShiftType int
+
+const (
+	OnCallShiftTypeUnknown OnCallShiftType = iota
+	OnCallShiftTypeSingleEvent
+	OnCallShiftTypeRecurrentEvent
+	OnCallShiftTypeRollingUsers
+	onCallShiftTypeLen
+)
+
+var onCallShiftTypeStringLookup = [onCallShiftTypeLen]string{
+	"unknown",
+	"single_event",
+	"recurrent_event",
+	"rolling_users",
+}
+
+func (o OnCallShiftType) String() string {
+	if o < 0 || o >= onCallShiftTypeLen {
+		return "unknown"
+	}
+
+	return onCallShiftTypeStringLookup[o]
+}
+
+func onCallShiftVariantFromString(s string) OnCallShiftVariant {
+	switch strings.ToLower(s) {
+	case "single_event":
+		return &OnCallShiftSingleEvent{}
+	case "recurrent_event":
+		return &OnCallShiftRecurrentEvent{}
+	case "rolling_users":
+		return &OnCallShiftRollingUsers{}
+	}
+
+	return nil
+}
+
+// OnCallShiftVariant determines who is on call during a shift.
+type OnCallShiftVariant interface {
+	OnCallShiftType() OnCallShiftType
+}
+
+// OnCallShiftSingleEvent puts the given users on call once, for the duration of
+// the shift. It should have no Recurrence.
+type OnCallShiftSingleEvent struct {
+	UserIDs []string `json:"users"`
+}
+
+func (o *OnCallShiftSingleEvent) OnCallShiftType() OnCallShiftType {
+	return OnCallShiftTypeSingleEvent
+}
+
+// OnCallShiftRecurrentEvent puts the same users on call every time the shift
+// recurs.
+type OnCallShiftRecurrentEvent struct {
+	UserIDs []string `json:"users"`
+}
+
+func (o *OnCallShiftRecurrentEvent) OnCallShiftType() OnCallShiftType {
+	return OnCallShiftTypeRecurrentEvent
+}
+
+// OnCallShiftRollingUsers puts the next group of users on call every time the
+// shift recurs.
+type OnCallShiftRollingUsers struct {
+	//UserIDs holds the groups of users which take turns being on call
+	UserIDs                    [][]string `json:"rolling_users"`
+	StartRotationFromUserIndex int        `json:"start_rotation_from_user_index"`
+}
+
+func (o *OnCallShiftRollingUsers) OnCallShiftType() OnCallShiftType {
+	return OnCallShiftTypeRollingUsers
+}
+
+type OnCallShiftFilter struct {
+	Name       string
+	ScheduleID string
+}
+
+func (f *OnCallShiftFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.Name != "" {
+			values.Set("name", f.Name)
+		}
+
+		if f.ScheduleID != "" {
+			values.Set("schedule_id", f.ScheduleID)
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListOnCallShiftsByPage(
+	page int,
+	filter *OnCallShiftFilter,
+) (*PaginatedResponse[OnCallShift], error) {
+	return c.ListOnCallShiftsByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListOnCallShiftsByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *OnCallShiftFilter,
+) (*PaginatedResponse[OnCallShift], error) {
+	return getPage[OnCallShift](ctx, c, page, onCallShiftPath, filter.values())
+}
+
+func (c *Client) ListOnCallShifts(filter *OnCallShiftFilter) ([]OnCallShift, error) {
+	return c.ListOnCallShiftsCtx(context.Background(), filter)
+}
+
+func (c *Client) ListOnCallShiftsCtx(
+	ctx context.Context,
+	filter *OnCallShiftFilter,
+) ([]OnCallShift, error) {
+	return paginate(c.OnCallShiftsIterCtx(ctx, filter))
+}
+
+func (c *Client) OnCallShiftsIter(filter *OnCallShiftFilter) *Iterator[OnCallShift] {
+	return c.OnCallShiftsIterCtx(context.Background(), filter)
+}
+
+func (c *Client) OnCallShiftsIterCtx(
+	ctx context.Context,
+	filter *OnCallShiftFilter,
+) *Iterator[OnCallShift] {
+	return newIterator[OnCallShift](ctx, c, onCallShiftPath, filter.values())
+}
+
+func (c *Client) GetOnCallShift(id string) (*OnCallShift, error) {
+	return c.GetOnCallShiftCtx(context.Background(), id)
+}
+
+func (c *Client) GetOnCallShiftCtx(ctx context.Context, id string) (*OnCallShift, error) {
+	ret := &OnCallShift{}
+	err := c.doRequest(ctx, "GET", buildPath(onCallShiftPath, id), nil, ret)
+	return ret, err
+}
+
+type CreateOnCallShiftOptions struct {
+	TeamID string
+	//TimeZone defaults to UTC
+	TimeZone   *time.Location
+	Level      int
+	Recurrence *OnCallShiftRecurrence
+}
+
+func (c *Client) CreateOnCallShift(
+	name string,
+	variant OnCallShiftVariant,
+	start time.Time,
+	duration time.Duration,
+	opts *CreateOnCallShiftOptions,
+) (*OnCallShift, error) {
+	return c.CreateOnCallShiftCtx(context.Background(), name, variant, start, duration, opts)
+}
+
+func (c *Client) CreateOnCallShiftCtx(
+	ctx context.Context,
+	name string,
+	variant OnCallShiftVariant,
+	start time.Time,
+	duration time.Duration,
+	opts *CreateOnCallShiftOptions,
+) (*OnCallShift, error) {
+
+	nonNilOpts := CreateOnCallShiftOptions{}
+	if opts != nil {
+		nonNilOpts = *opts
+	}
+
+	shiftOut := &OnCallShift{
+		Name:       name,
+		TeamID:     nonNilOpts.TeamID,
+		TimeZone:   nonNilOpts.TimeZone,
+		Level:      nonNilOpts.Level,
+		Start:      start,
+		Duration:   duration,
+		Recurrence: nonNilOpts.Recurrence,
+		Variant:    variant,
+	}
+
+	ret := &OnCallShift{}
+	err := c.doRequest(ctx, "POST", onCallShiftPath, shiftOut, ret)
+	return ret, err
+}
+
+// UpdateOnCallShiftOptions holds the fields to change on an on-call shift. Only
+// non-nil fields are sent.
+type UpdateOnCallShiftOptions struct {
+	Name     *string
+	TeamID   *string
+	TimeZone *time.Location
+	Level    *int
+	//Start is sent as a wall-clock time in TimeZone if it is non-nil, or in the
+	//location of Start otherwise.
+	Start      *time.Time
+	Duration   *time.Duration
+	Recurrence *OnCallShiftRecurrence
+	//If Variant is non-nil, the shift's type and users are replaced.
+	Variant OnCallShiftVariant
+}
+
+func (c *Client) UpdateOnCallShift(
+	id string,
+	opts *UpdateOnCallShiftOptions,
+) (*OnCallShift, error) {
+	return c.UpdateOnCallShiftCtx(context.Background(), id, opts)
+}
+
+func (c *Client) UpdateOnCallShiftCtx(
+	ctx context.Context,
+	id string,
+	opts *UpdateOnCallShiftOptions,
+) (*OnCallShift, error) {
+
+	requestBody := map[string]interface{}{}
+	if opts != nil {
+		if opts.Variant != nil {
+			inter, err := json.Marshal(&opts.Variant)
+			if err != nil {
+				return nil, err
+			}
+
+			err = json.Unmarshal(inter, &requestBody)
+			if err != nil {
+				return nil, err
+			}
+
+			requestBody["type"] = opts.Variant.OnCallShiftType().String()
+		}
+
+		var loc *time.Location
+		if opts.TimeZone != nil {
+			loc = opts.TimeZone
+			requestBody["time_zone"] = opts.TimeZone.String()
+		}
+		if opts.Start != nil {
+			startLoc := loc
+			if startLoc == nil {
+				startLoc = opts.Start.Location()
+			}
+			requestBody["start"] = opts.Start.In(startLoc).Format(onCallShiftTimeLayout)
+		}
+		if opts.Recurrence != nil {
+			recurrenceLoc := loc
+			if recurrenceLoc == nil {
+				recurrenceLoc = opts.Recurrence.Until.Location()
+			}
+			opts.Recurrence.addFields(requestBody, recurrenceLoc)
+		}
+
+		if opts.Name != nil {
+			requestBody["name"] = *opts.Name
+		}
+		if opts.TeamID != nil {
+			requestBody["team_id"] = *opts.TeamID
+		}
+		if opts.Level != nil {
+			requestBody["level"] = *opts.Level
+		}
+		if opts.Duration != nil {
+			requestBody["duration"] = int64(opts.Duration.Seconds())
+		}
+	}
+
+	ret := &OnCallShift{}
+	err := c.doRequest(ctx, "PUT", buildPath(onCallShiftPath, id), &requestBody, ret)
+	return ret, err
+}
+
+func (c *Client) DeleteOnCallShift(id string) error {
+	return c.DeleteOnCallShiftCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteOnCallShiftCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(onCallShiftPath, id), nil, nil)
+}
@@ -0,0 +1,106 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const organizationPath = "organization"
+
+type Organization struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (c *Client) GetOrganization() (*Organization, error) {
+	return c.GetOrganizationCtx(context.Background())
+}
+
+func (c *Client) GetOrganizationCtx(ctx context.Context) (*Organization, error) {
+	ret := &Organization{}
+	err := c.doRequest(ctx, "GET", organizationPath, nil, ret)
+	return ret, err
+}
+
+var (
+	//ErrInvalidToken is matched by errors returned from Ping when the API
+	//rejects the AuthToken of the Client.
+	ErrInvalidToken = errors.New("auth token was rejected")
+	//ErrWrongURL is matched by errors returned from Ping when the server
+	//responds, but not as the OnCall API would.
+	ErrWrongURL = errors.New("URL does not point to the OnCall API")
+	//ErrUnreachable is matched by errors returned from Ping when the server
+	//could not be reached at all.
+	ErrUnreachable = errors.New("server is unreachable")
+)
+
+// PingError is returned by Ping when the Client is misconfigured. Use errors.Is
+// with ErrInvalidToken, ErrWrongURL, or ErrUnreachable to tell why.
+type PingError struct {
+	//Reason is one of ErrInvalidToken, ErrWrongURL, or ErrUnreachable
+	Reason error
+	//Err is the underlying error
+	Err error
+}
+
+func (e *PingError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Err)
+}
+
+func (e *PingError) Unwrap() error { return e.Err }
+
+func (e *PingError) Is(target error) bool { return target == e.Reason }
+
+// Ping checks that the Client can reach the OnCall API with its AuthToken, and
+// returns the organization the token belongs to. Errors caused by a bad token,
+// a wrong URL, or an unreachable server are returned as a *PingError.
+func (c *Client) Ping() (*Organization, error) {
+	return c.PingCtx(context.Background())
+}
+
+func (c *Client) PingCtx(ctx context.Context) (*Organization, error) {
+	org, err := c.GetOrganizationCtx(ctx)
+	if err == nil {
+		if org.ID == "" {
+			return nil, &PingError{
+				Reason: ErrWrongURL,
+				Err:    errors.New("response did not contain an organization ID"),
+			}
+		}
+
+		return org, nil
+	}
+
+	if ctx.Err() != nil {
+		return nil, err
+	}
+
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return nil, &PingError{Reason: ErrInvalidToken, Err: err}
+		case http.StatusNotFound:
+			return nil, &PingError{Reason: ErrWrongURL, Err: err}
+		}
+
+		return nil, err
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return nil, &PingError{Reason: ErrWrongURL, Err: err}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return nil, &PingError{Reason: ErrUnreachable, Err: err}
+	}
+
+	return nil, err
+}
@@ -0,0 +1,266 @@
+package oncall
+
+import (
+	"context"
+	"net/url"
+)
+
+const outgoingWebhookPath = "webhooks"
+
+// OutgoingWebhook is an HTTP request made by OnCall when an alert group event
+// occurs. Webhooks with the escalation trigger type are triggered by
+// EscalationPolicyRuleTriggerAction.
+type OutgoingWebhook struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	TeamID  string `json:"team"`
+	Enabled bool   `json:"is_webhook_enabled"`
+	URL     string `json:"url"`
+	//HTTPMethod is the method of the request, such as "POST"
+	HTTPMethod string `json:"http_method"`
+	//Headers is a JSON object of request headers, which may use templates
+	Headers string `json:"headers"`
+	//Data is the template of the request body. It is ignored if ForwardAll is
+	//true.
+	Data string `json:"data"`
+	//ForwardAll sends the whole alert group payload as the request body
+	ForwardAll          bool                       `json:"forward_all"`
+	Username            string                     `json:"username"`
+	Password            string                     `json:"password"`
+	AuthorizationHeader string                     `json:"authorization_header"`
+	TriggerType         OutgoingWebhookTriggerType `json:"trigger_type"`
+	//TriggerTemplate is a template which must evaluate to "True" for the webhook
+	//to be sent
+	TriggerTemplate string `json:"trigger_template"`
+	//IntegrationFilter limits the webhook to alert groups of these integrations.
+	//If empty, alert groups of all integrations trigger the webhook.
+	IntegrationFilter []string `json:"integration_filter"`
+}
+
+type OutgoingWebhookTriggerType string
+
+const (
+	OutgoingWebhookTriggerTypeEscalation        OutgoingWebhookTriggerType = "escalation"
+	OutgoingWebhookTriggerTypeAlertGroupCreated OutgoingWebhookTriggerType = "alert group created"
+	OutgoingWebhookTriggerTypeAcknowledge       OutgoingWebhookTriggerType = "acknowledge"
+	OutgoingWebhookTriggerTypeUnacknowledge     OutgoingWebhookTriggerType = "unacknowledge"
+	OutgoingWebhookTriggerTypeResolve           OutgoingWebhookTriggerType = "resolve"
+	OutgoingWebhookTriggerTypeUnresolve         OutgoingWebhookTriggerType = "unresolve"
+	OutgoingWebhookTriggerTypeSilence           OutgoingWebhookTriggerType = "silence"
+	OutgoingWebhookTriggerTypeUnsilence         OutgoingWebhookTriggerType = "unsilence"
+)
+
+type OutgoingWebhookFilter struct {
+	Name string
+}
+
+func (f *OutgoingWebhookFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.Name != "" {
+			values.Set("name", f.Name)
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListOutgoingWebhooksByPage(
+	page int,
+	filter *OutgoingWebhookFilter,
+) (*PaginatedResponse[OutgoingWebhook], error) {
+	return c.ListOutgoingWebhooksByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListOutgoingWebhooksByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *OutgoingWebhookFilter,
+) (*PaginatedResponse[OutgoingWebhook], error) {
+	return getPage[OutgoingWebhook](ctx, c, page, outgoingWebhookPath, filter.values())
+}
+
+func (c *Client) ListOutgoingWebhooks(filter *OutgoingWebhookFilter) ([]OutgoingWebhook, error) {
+	return c.ListOutgoingWebhooksCtx(context.Background(), filter)
+}
+
+func (c *Client) ListOutgoingWebhooksCtx(
+	ctx context.Context,
+	filter *OutgoingWebhookFilter,
+) ([]OutgoingWebhook, error) {
+	return paginate(c.OutgoingWebhooksIterCtx(ctx, filter))
+}
+
+func (c *Client) OutgoingWebhooksIter(filter *OutgoingWebhookFilter) *Iterator[OutgoingWebhook] {
+	return c.OutgoingWebhooksIterCtx(context.Background(), filter)
+}
+
+func (c *Client) OutgoingWebhooksIterCtx(
+	ctx context.Context,
+	filter *OutgoingWebhookFilter,
+) *Iterator[OutgoingWebhook] {
+	return newIterator[OutgoingWebhook](ctx, c, outgoingWebhookPath, filter.values())
+}
+
+func (c *Client) GetOutgoingWebhook(id string) (*OutgoingWebhook, error) {
+	return c.GetOutgoingWebhookCtx(context.Background(), id)
+}
+
+func (c *Client) GetOutgoingWebhookCtx(ctx context.Context, id string) (*OutgoingWebhook, error) {
+	ret := &OutgoingWebhook{}
+	err := c.doRequest(ctx, "GET", buildPath(outgoingWebhookPath, id), nil, ret)
+	return ret, err
+}
+
+type CreateOutgoingWebhookOptions struct {
+	TeamID string
+	//HTTPMethod defaults to "POST"
+	HTTPMethod          string
+	Headers             string
+	Data                string
+	ForwardAll          bool
+	Username            string
+	Password            string
+	AuthorizationHeader string
+	TriggerTemplate     string
+	IntegrationFilter   []string
+	//Webhooks are created enabled unless Disabled is true
+	Disabled bool
+}
+
+func (c *Client) CreateOutgoingWebhook(
+	name string,
+	webhookURL string,
+	triggerType OutgoingWebhookTriggerType,
+	opts *CreateOutgoingWebhookOptions,
+) (*OutgoingWebhook, error) {
+	return c.CreateOutgoingWebhookCtx(context.Background(), name, webhookURL, triggerType, opts)
+}
+
+func (c *Client) CreateOutgoingWebhookCtx(
+	ctx context.Context,
+	name string,
+	webhookURL string,
+	triggerType OutgoingWebhookTriggerType,
+	opts *CreateOutgoingWebhookOptions,
+) (*OutgoingWebhook, error) {
+
+	requestBody := struct {
+		Name                string                     `json:"name"`
+		URL                 string                     `json:"url"`
+		TriggerType         OutgoingWebhookTriggerType `json:"trigger_type"`
+		Enabled             bool                       `json:"is_webhook_enabled"`
+		HTTPMethod          string                     `json:"http_method"`
+		TeamID              string                     `json:"team,omitempty"`
+		Headers             string                     `json:"headers,omitempty"`
+		Data                string                     `json:"data,omitempty"`
+		ForwardAll          bool                       `json:"forward_all"`
+		Username            string                     `json:"username,omitempty"`
+		Password            string                     `json:"password,omitempty"`
+		AuthorizationHeader string                     `json:"authorization_header,omitempty"`
+		TriggerTemplate     string                     `json:"trigger_template,omitempty"`
+		IntegrationFilter   []string                   `json:"integration_filter,omitempty"`
+	}{
+		Name:        name,
+		URL:         webhookURL,
+		TriggerType: triggerType,
+		Enabled:     true,
+		HTTPMethod:  "POST",
+	}
+
+	if opts != nil {
+		if opts.HTTPMethod != "" {
+			requestBody.HTTPMethod = opts.HTTPMethod
+		}
+		requestBody.Enabled = !opts.Disabled
+		requestBody.TeamID = opts.TeamID
+		requestBody.Headers = opts.Headers
+		requestBody.Data = opts.Data
+		requestBody.ForwardAll = opts.ForwardAll
+		requestBody.Username = opts.Username
+		requestBody.Password = opts.Password
+		requestBody.AuthorizationHeader = opts.AuthorizationHeader
+		requestBody.TriggerTemplate = opts.TriggerTemplate
+		requestBody.IntegrationFilter = opts.IntegrationFilter
+	}
+
+	ret := &OutgoingWebhook{}
+	err := c.doRequest(ctx, "POST", outgoingWebhookPath, &requestBody, ret)
+	return ret, err
+}
+
+// UpdateOutgoingWebhookOptions holds the fields to change on an outgoing
+// webhook. Only non-nil fields are sent.
+type UpdateOutgoingWebhookOptions struct {
+	Name                *string
+	Enabled             *bool
+	URL                 *string
+	HTTPMethod          *string
+	Headers             *string
+	Data                *string
+	ForwardAll          *bool
+	Username            *string
+	Password            *string
+	AuthorizationHeader *string
+	TriggerType         *OutgoingWebhookTriggerType
+	TriggerTemplate     *string
+	IntegrationFilter   *[]string
+}
+
+func (c *Client) UpdateOutgoingWebhook(
+	id string,
+	opts *UpdateOutgoingWebhookOptions,
+) (*OutgoingWebhook, error) {
+	return c.UpdateOutgoingWebhookCtx(context.Background(), id, opts)
+}
+
+func (c *Client) UpdateOutgoingWebhookCtx(
+	ctx context.Context,
+	id string,
+	opts *UpdateOutgoingWebhookOptions,
+) (*OutgoingWebhook, error) {
+
+	requestBody := struct {
+		Name                *string                     `json:"name,omitempty"`
+		Enabled             *bool                       `json:"is_webhook_enabled,omitempty"`
+		URL                 *string                     `json:"url,omitempty"`
+		HTTPMethod          *string                     `json:"http_method,omitempty"`
+		Headers             *string                     `json:"headers,omitempty"`
+		Data                *string                     `json:"data,omitempty"`
+		ForwardAll          *bool                       `json:"forward_all,omitempty"`
+		Username            *string                     `json:"username,omitempty"`
+		Password            *string                     `json:"password,omitempty"`
+		AuthorizationHeader *string                     `json:"authorization_header,omitempty"`
+		TriggerType         *OutgoingWebhookTriggerType `json:"trigger_type,omitempty"`
+		TriggerTemplate     *string                     `json:"trigger_template,omitempty"`
+		IntegrationFilter   *[]string                   `json:"integration_filter,omitempty"`
+	}{}
+
+	if opts != nil {
+		requestBody.Name = opts.Name
+		requestBody.Enabled = opts.Enabled
+		requestBody.URL = opts.URL
+		requestBody.HTTPMethod = opts.HTTPMethod
+		requestBody.Headers = opts.Headers
+		requestBody.Data = opts.Data
+		requestBody.ForwardAll = opts.ForwardAll
+		requestBody.Username = opts.Username
+		requestBody.Password = opts.Password
+		requestBody.AuthorizationHeader = opts.AuthorizationHeader
+		requestBody.TriggerType = opts.TriggerType
+		requestBody.TriggerTemplate = opts.TriggerTemplate
+		requestBody.IntegrationFilter = opts.IntegrationFilter
+	}
+
+	ret := &OutgoingWebhook{}
+	err := c.doRequest(ctx, "PUT", buildPath(outgoingWebhookPath, id), &requestBody, ret)
+	return ret, err
+}
+
+func (c *Client) DeleteOutgoingWebhook(id string) error {
+	return c.DeleteOutgoingWebhookCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteOutgoingWebhookCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(outgoingWebhookPath, id), nil, nil)
+}
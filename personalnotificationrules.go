@@ -0,0 +1,271 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const personalNotificationRulePath = "personal_notification_rules"
+
+// PersonalNotificationRule is a step in the sequence a user is notified
+// through when they are paged. Important rules are used for important
+// escalations.
+type PersonalNotificationRule struct {
+	ID        string
+	UserID    string
+	Position  int
+	Important bool
+	Type      PersonalNotificationRuleType
+	//Duration is only used by wait rules
+	Duration time.Duration
+}
+
+type personalNotificationRuleRaw struct {
+	ID        string                       `json:"id"`
+	UserID    string                       `json:"user_id"`
+	Position  int                          `json:"position"`
+	Important bool                         `json:"important"`
+	Type      PersonalNotificationRuleType `json:"type"`
+	Duration  int64                        `json:"duration,omitempty"`
+}
+
+func (p *PersonalNotificationRule) UnmarshalJSON(b []byte) error {
+	raw := personalNotificationRuleRaw{}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	*p = PersonalNotificationRule{
+		ID:        raw.ID,
+		UserID:    raw.UserID,
+		Position:  raw.Position,
+		Important: raw.Important,
+		Type:      raw.Type,
+		Duration:  time.Duration(raw.Duration) * time.Second,
+	}
+
+	return nil
+}
+
+func (p *PersonalNotificationRule) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(&personalNotificationRuleRaw{
+		ID:        p.ID,
+		UserID:    p.UserID,
+		Position:  p.Position,
+		Important: p.Important,
+		Type:      p.Type,
+		Duration:  int64(p.Duration.Seconds()),
+	})
+}
+
+type PersonalNotificationRuleType string
+
+const (
+	PersonalNotificationRuleTypeWait                      PersonalNotificationRuleType = "wait"
+	PersonalNotificationRuleTypeNotifyBySlack             PersonalNotificationRuleType = "notify_by_slack"
+	PersonalNotificationRuleTypeNotifyBySMS               PersonalNotificationRuleType = "notify_by_sms"
+	PersonalNotificationRuleTypeNotifyByPhoneCall         PersonalNotificationRuleType = "notify_by_phone_call"
+	PersonalNotificationRuleTypeNotifyByTelegram          PersonalNotificationRuleType = "notify_by_telegram"
+	PersonalNotificationRuleTypeNotifyByEmail             PersonalNotificationRuleType = "notify_by_email"
+	PersonalNotificationRuleTypeNotifyByMobileApp         PersonalNotificationRuleType = "notify_by_mobile_app"
+	PersonalNotificationRuleTypeNotifyByMobileAppCritical PersonalNotificationRuleType = "notify_by_mobile_app_critical"
+)
+
+type PersonalNotificationRuleFilter struct {
+	UserID string
+	//If Important is non-nil, only rules with the given importance are returned
+	Important *bool
+}
+
+func (f *PersonalNotificationRuleFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.UserID != "" {
+			values.Set("user_id", f.UserID)
+		}
+
+		if f.Important != nil {
+			values.Set("important", strconv.FormatBool(*f.Important))
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListPersonalNotificationRulesByPage(
+	page int,
+	filter *PersonalNotificationRuleFilter,
+) (*PaginatedResponse[PersonalNotificationRule], error) {
+	return c.ListPersonalNotificationRulesByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListPersonalNotificationRulesByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *PersonalNotificationRuleFilter,
+) (*PaginatedResponse[PersonalNotificationRule], error) {
+	return getPage[PersonalNotificationRule](
+		ctx,
+		c,
+		page,
+		personalNotificationRulePath,
+		filter.values(),
+	)
+}
+
+func (c *Client) ListPersonalNotificationRules(
+	filter *PersonalNotificationRuleFilter,
+) ([]PersonalNotificationRule, error) {
+	return c.ListPersonalNotificationRulesCtx(context.Background(), filter)
+}
+
+func (c *Client) ListPersonalNotificationRulesCtx(
+	ctx context.Context,
+	filter *PersonalNotificationRuleFilter,
+) ([]PersonalNotificationRule, error) {
+	return paginate(c.PersonalNotificationRulesIterCtx(ctx, filter))
+}
+
+func (c *Client) PersonalNotificationRulesIter(
+	filter *PersonalNotificationRuleFilter,
+) *Iterator[PersonalNotificationRule] {
+	return c.PersonalNotificationRulesIterCtx(context.Background(), filter)
+}
+
+func (c *Client) PersonalNotificationRulesIterCtx(
+	ctx context.Context,
+	filter *PersonalNotificationRuleFilter,
+) *Iterator[PersonalNotificationRule] {
+	return newIterator[PersonalNotificationRule](
+		ctx,
+		c,
+		personalNotificationRulePath,
+		filter.values(),
+	)
+}
+
+func (c *Client) GetPersonalNotificationRule(id string) (*PersonalNotificationRule, error) {
+	return c.GetPersonalNotificationRuleCtx(context.Background(), id)
+}
+
+func (c *Client) GetPersonalNotificationRuleCtx(
+	ctx context.Context,
+	id string,
+) (*PersonalNotificationRule, error) {
+
+	ret := &PersonalNotificationRule{}
+	err := c.doRequest(ctx, "GET", buildPath(personalNotificationRulePath, id), nil, ret)
+	return ret, err
+}
+
+type CreatePersonalNotificationRuleOptions struct {
+	//If Position is nil, the rule is added at the end of the user's rules
+	Position  *int
+	Important bool
+	//Duration is only used by wait rules, with a precision of seconds
+	Duration time.Duration
+}
+
+func (c *Client) CreatePersonalNotificationRule(
+	userID string,
+	ruleType PersonalNotificationRuleType,
+	opts *CreatePersonalNotificationRuleOptions,
+) (*PersonalNotificationRule, error) {
+	return c.CreatePersonalNotificationRuleCtx(context.Background(), userID, ruleType, opts)
+}
+
+func (c *Client) CreatePersonalNotificationRuleCtx(
+	ctx context.Context,
+	userID string,
+	ruleType PersonalNotificationRuleType,
+	opts *CreatePersonalNotificationRuleOptions,
+) (*PersonalNotificationRule, error) {
+
+	requestBody := struct {
+		UserID      string                       `json:"user_id"`
+		Type        PersonalNotificationRuleType `json:"type"`
+		Position    *int                         `json:"position,omitempty"`
+		ManualOrder bool                         `json:"manual_order,omitempty"`
+		Important   bool                         `json:"important"`
+		Duration    int64                        `json:"duration,omitempty"`
+	}{
+		UserID: userID,
+		Type:   ruleType,
+	}
+
+	if opts != nil {
+		requestBody.Position = opts.Position
+		requestBody.ManualOrder = opts.Position != nil
+		requestBody.Important = opts.Important
+		requestBody.Duration = int64(opts.Duration.Seconds())
+	}
+
+	ret := &PersonalNotificationRule{}
+	err := c.doRequest(ctx, "POST", personalNotificationRulePath, &requestBody, ret)
+	return ret, err
+}
+
+// UpdatePersonalNotificationRuleOptions holds the fields to change on a
+// personal notification rule. Only non-nil fields are sent.
+type UpdatePersonalNotificationRuleOptions struct {
+	Type     *PersonalNotificationRuleType
+	Position *int
+	Duration *time.Duration
+}
+
+func (c *Client) UpdatePersonalNotificationRule(
+	id string,
+	opts *UpdatePersonalNotificationRuleOptions,
+) (*PersonalNotificationRule, error) {
+	return c.UpdatePersonalNotificationRuleCtx(context.Background(), id, opts)
+}
+
+func (c *Client) UpdatePersonalNotificationRuleCtx(
+	ctx context.Context,
+	id string,
+	opts *UpdatePersonalNotificationRuleOptions,
+) (*PersonalNotificationRule, error) {
+
+	requestBody := struct {
+		Type        *PersonalNotificationRuleType `json:"type,omitempty"`
+		Position    *int                          `json:"position,omitempty"`
+		ManualOrder bool                          `json:"manual_order,omitempty"`
+		Duration    *int64                        `json:"duration,omitempty"`
+	}{}
+
+	if opts != nil {
+		requestBody.Type = opts.Type
+		requestBody.Position = opts.Position
+		requestBody.ManualOrder = opts.Position != nil
+		if opts.Duration != nil {
+			duration := int64(opts.Duration.Seconds())
+			requestBody.Duration = &duration
+		}
+	}
+
+	ret := &PersonalNotificationRule{}
+	err := c.doRequest(
+		ctx,
+		"PUT",
+		buildPath(personalNotificationRulePath, id),
+		&requestBody,
+		ret,
+	)
+	return ret, err
+}
+
+func (c *Client) DeletePersonalNotificationRule(id string) error {
+	return c.DeletePersonalNotificationRuleCtx(context.Background(), id)
+}
+
+func (c *Client) DeletePersonalNotificationRuleCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(personalNotificationRulePath, id), nil, nil)
+}
@@ -0,0 +1,117 @@
+package oncall
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate at which requests are made.
+// It is safe for concurrent use, and a single RateLimiter can be shared by
+// multiple Clients.
+type RateLimiter struct {
+	//If OnWait is non-nil, it is called with the time a request spent waiting
+	//for the limiter, whenever that time is non-zero. It must be set before the
+	//RateLimiter is used.
+	OnWait func(time.Duration)
+
+	lock      sync.Mutex
+	rate      float64
+	burst     float64
+	tokens    float64
+	last      time.Time
+	totalWait time.Duration
+}
+
+// NewRateLimiter returns a RateLimiter which allows requestsPerSecond requests
+// per second on average, with bursts of up to burst requests. burst values
+// less than 1 are treated as 1.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request is allowed to proceed or ctx is done. It returns
+// the time spent waiting. A nil RateLimiter never waits.
+func (r *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	if r == nil || r.rate <= 0 {
+		return 0, ctx.Err()
+	}
+
+	wait := r.reserve()
+	if wait <= 0 {
+		return 0, ctx.Err()
+	}
+
+	start := time.Now()
+	timer := time.NewTimer(wait)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		r.cancel()
+		return time.Since(start), ctx.Err()
+	case <-timer.C:
+	}
+
+	r.lock.Lock()
+	r.totalWait += wait
+	r.lock.Unlock()
+
+	if r.OnWait != nil {
+		r.OnWait(wait)
+	}
+
+	return wait, nil
+}
+
+// TotalWait returns the cumulative time requests have spent waiting for the
+// RateLimiter.
+func (r *RateLimiter) TotalWait() time.Duration {
+	if r == nil {
+		return 0
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.totalWait
+}
+
+// reserve takes a token from the bucket and returns how long the caller must
+// wait before the token is available.
+func (r *RateLimiter) reserve() time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	r.tokens += now.Sub(r.last).Seconds() * r.rate
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+	r.last = now
+
+	r.tokens--
+	if r.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-r.tokens / r.rate * float64(time.Second))
+}
+
+// cancel returns a token reserved by a caller which gave up waiting for it.
+func (r *RateLimiter) cancel() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.tokens++
+	if r.tokens > r.burst {
+		r.tokens = r.burst
+	}
+}
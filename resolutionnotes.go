@@ -0,0 +1,193 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+const resolutionNotePath = "resolution_notes"
+
+type ResolutionNote struct {
+	ID           string
+	AlertGroupID string
+	//AuthorID is the ID of the user who wrote the note. It can be empty if the
+	//author is not an OnCall user.
+	AuthorID  string
+	Source    ResolutionNoteSource
+	CreatedAt time.Time
+	Text      string
+}
+
+type resolutionNoteRaw struct {
+	ID           string               `json:"id"`
+	AlertGroupID string               `json:"alert_group_id"`
+	Author       string               `json:"author"`
+	Source       ResolutionNoteSource `json:"source"`
+	CreatedAt    string               `json:"created_at"`
+	Text         string               `json:"text"`
+}
+
+func (r *ResolutionNote) UnmarshalJSON(b []byte) error {
+	raw := resolutionNoteRaw{}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	parsedCreatedAt, err := timeFromString(raw.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	*r = ResolutionNote{
+		ID:           raw.ID,
+		AlertGroupID: raw.AlertGroupID,
+		AuthorID:     raw.Author,
+		Source:       raw.Source,
+		CreatedAt:    parsedCreatedAt,
+		Text:         raw.Text,
+	}
+
+	return nil
+}
+
+func (r *ResolutionNote) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(&resolutionNoteRaw{
+		ID:           r.ID,
+		AlertGroupID: r.AlertGroupID,
+		Author:       r.AuthorID,
+		Source:       r.Source,
+		CreatedAt:    timeToString(r.CreatedAt),
+		Text:         r.Text,
+	})
+}
+
+// ResolutionNoteSource is where a resolution note was written.
+type ResolutionNoteSource string
+
+const (
+	ResolutionNoteSourceWeb       ResolutionNoteSource = "web"
+	ResolutionNoteSourceSlack     ResolutionNoteSource = "slack"
+	ResolutionNoteSourceTelegram  ResolutionNoteSource = "telegram"
+	ResolutionNoteSourceMobileApp ResolutionNoteSource = "mobile_app"
+)
+
+type ResolutionNoteFilter struct {
+	AlertGroupID string
+}
+
+func (f *ResolutionNoteFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.AlertGroupID != "" {
+			values.Set("alert_group_id", f.AlertGroupID)
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListResolutionNotesByPage(
+	page int,
+	filter *ResolutionNoteFilter,
+) (*PaginatedResponse[ResolutionNote], error) {
+	return c.ListResolutionNotesByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListResolutionNotesByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *ResolutionNoteFilter,
+) (*PaginatedResponse[ResolutionNote], error) {
+	return getPage[ResolutionNote](ctx, c, page, resolutionNotePath, filter.values())
+}
+
+func (c *Client) ListResolutionNotes(filter *ResolutionNoteFilter) ([]ResolutionNote, error) {
+	return c.ListResolutionNotesCtx(context.Background(), filter)
+}
+
+func (c *Client) ListResolutionNotesCtx(
+	ctx context.Context,
+	filter *ResolutionNoteFilter,
+) ([]ResolutionNote, error) {
+	return paginate(c.ResolutionNotesIterCtx(ctx, filter))
+}
+
+func (c *Client) ResolutionNotesIter(filter *ResolutionNoteFilter) *Iterator[ResolutionNote] {
+	return c.ResolutionNotesIterCtx(context.Background(), filter)
+}
+
+func (c *Client) ResolutionNotesIterCtx(
+	ctx context.Context,
+	filter *ResolutionNoteFilter,
+) *Iterator[ResolutionNote] {
+	return newIterator[ResolutionNote](ctx, c, resolutionNotePath, filter.values())
+}
+
+func (c *Client) GetResolutionNote(id string) (*ResolutionNote, error) {
+	return c.GetResolutionNoteCtx(context.Background(), id)
+}
+
+func (c *Client) GetResolutionNoteCtx(ctx context.Context, id string) (*ResolutionNote, error) {
+	ret := &ResolutionNote{}
+	err := c.doRequest(ctx, "GET", buildPath(resolutionNotePath, id), nil, ret)
+	return ret, err
+}
+
+func (c *Client) CreateResolutionNote(alertGroupID, text string) (*ResolutionNote, error) {
+	return c.CreateResolutionNoteCtx(context.Background(), alertGroupID, text)
+}
+
+func (c *Client) CreateResolutionNoteCtx(
+	ctx context.Context,
+	alertGroupID string,
+	text string,
+) (*ResolutionNote, error) {
+
+	requestBody := struct {
+		AlertGroupID string `json:"alert_group_id"`
+		Text         string `json:"text"`
+	}{
+		AlertGroupID: alertGroupID,
+		Text:         text,
+	}
+
+	ret := &ResolutionNote{}
+	err := c.doRequest(ctx, "POST", resolutionNotePath, &requestBody, ret)
+	return ret, err
+}
+
+func (c *Client) UpdateResolutionNote(id, text string) (*ResolutionNote, error) {
+	return c.UpdateResolutionNoteCtx(context.Background(), id, text)
+}
+
+func (c *Client) UpdateResolutionNoteCtx(
+	ctx context.Context,
+	id string,
+	text string,
+) (*ResolutionNote, error) {
+
+	requestBody := struct {
+		Text string `json:"text"`
+	}{
+		Text: text,
+	}
+
+	ret := &ResolutionNote{}
+	err := c.doRequest(ctx, "PUT", buildPath(resolutionNotePath, id), &requestBody, ret)
+	return ret, err
+}
+
+func (c *Client) DeleteResolutionNote(id string) error {
+	return c.DeleteResolutionNoteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteResolutionNoteCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(resolutionNotePath, id), nil, nil)
+}
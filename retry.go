@@ -0,0 +1,179 @@
+package oncall
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 4
+	defaultRetryMinBackoff  = 500 * time.Millisecond
+	defaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// RetryPolicy configures how requests are retried. Requests are retried when
+// the server responds with a 429 or with a 502, 503, or 504, or when the
+// request fails at the transport level. Only idempotent methods are retried,
+// except for 429 responses, which signal that the request was not processed.
+type RetryPolicy struct {
+	//MaxAttempts is the total number of attempts made, including the first one.
+	//Values less than 1 are treated as 1.
+	MaxAttempts int
+	//MinBackoff is the base wait before the first retry. It is doubled for each
+	//subsequent retry. Defaults to 500ms.
+	MinBackoff time.Duration
+	//MaxBackoff caps the exponential backoff. It does not cap waits requested by
+	//the server through a Retry-After header. Defaults to 30s.
+	MaxBackoff time.Duration
+	//If RetryNonIdempotent is true, POST and PATCH requests are retried on
+	//transport errors and 5xx responses as well.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy which makes up to 4 attempts with
+// the default backoff.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		MinBackoff:  defaultRetryMinBackoff,
+		MaxBackoff:  defaultRetryMaxBackoff,
+	}
+}
+
+// do calls fn until it returns a result that should not be retried, the
+// attempts are exhausted, or ctx is done. A nil policy calls fn exactly once.
+func (p *RetryPolicy) do(
+	ctx context.Context,
+	method string,
+	fn func() (*http.Response, error),
+) (*http.Response, error) {
+
+	if p == nil {
+		return fn()
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := fn()
+		if attempt >= p.MaxAttempts || !p.shouldRetry(ctx, method, resp, err) {
+			return resp, err
+		}
+
+		wait := p.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = retryAfter
+			}
+
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(
+	ctx context.Context,
+	method string,
+	resp *http.Response,
+	err error,
+) bool {
+
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns the wait before the retry following the given attempt. Half
+// of the wait is fixed, and the other half is random jitter.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	minBackoff := p.MinBackoff
+	if minBackoff <= 0 {
+		minBackoff = defaultRetryMinBackoff
+	}
+
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+
+	wait := maxBackoff
+	if attempt <= 32 {
+		if exp := minBackoff << (attempt - 1); exp > 0 && exp < maxBackoff {
+			wait = exp
+		}
+	}
+
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which may be given
+// either in seconds or as an HTTP date.
+func parseRetryAfter(s string) (time.Duration, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(s); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(s)
+	if err != nil {
+		return 0, false
+	}
+
+	wait := time.Until(t)
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait, true
+}
@@ -0,0 +1,211 @@
+package oncall
+
+import (
+	"context"
+	"net/url"
+)
+
+const routePath = "routes"
+
+// Route sends the alerts of an integration which match its routing expression
+// to an escalation chain.
+type Route struct {
+	ID                string                `json:"id"`
+	IntegrationID     string                `json:"integration_id"`
+	EscalationChainID string                `json:"escalation_chain_id"`
+	RoutingType       RouteRoutingType      `json:"routing_type"`
+	RoutingRegex      string                `json:"routing_regex"`
+	Position          int                   `json:"position"`
+	IsTheLastRoute    bool                  `json:"is_the_last_route"`
+	Slack             RouteSlackSettings    `json:"slack"`
+	Telegram          RouteTelegramSettings `json:"telegram"`
+}
+
+type RouteSlackSettings struct {
+	ChannelID string `json:"channel_id,omitempty"`
+	Enabled   bool   `json:"enabled"`
+}
+
+type RouteTelegramSettings struct {
+	ID      string `json:"id,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+// RouteRoutingType determines how the RoutingRegex of a Route is interpreted.
+type RouteRoutingType string
+
+const (
+	//RoutingRegex is matched as a regular expression against the alert payload
+	RouteRoutingTypeRegex RouteRoutingType = "regex"
+	//RoutingRegex is a Jinja2 template which must evaluate to "True"
+	RouteRoutingTypeJinja2 RouteRoutingType = "jinja2"
+)
+
+type RouteFilter struct {
+	IntegrationID string
+	RoutingRegex  string
+}
+
+func (f *RouteFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.IntegrationID != "" {
+			values.Set("integration_id", f.IntegrationID)
+		}
+
+		if f.RoutingRegex != "" {
+			values.Set("routing_regex", f.RoutingRegex)
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListRoutesByPage(
+	page int,
+	filter *RouteFilter,
+) (*PaginatedResponse[Route], error) {
+	return c.ListRoutesByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListRoutesByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *RouteFilter,
+) (*PaginatedResponse[Route], error) {
+	return getPage[Route](ctx, c, page, routePath, filter.values())
+}
+
+func (c *Client) ListRoutes(filter *RouteFilter) ([]Route, error) {
+	return c.ListRoutesCtx(context.Background(), filter)
+}
+
+func (c *Client) ListRoutesCtx(ctx context.Context, filter *RouteFilter) ([]Route, error) {
+	return paginate(c.RoutesIterCtx(ctx, filter))
+}
+
+func (c *Client) RoutesIter(filter *RouteFilter) *Iterator[Route] {
+	return c.RoutesIterCtx(context.Background(), filter)
+}
+
+func (c *Client) RoutesIterCtx(ctx context.Context, filter *RouteFilter) *Iterator[Route] {
+	return newIterator[Route](ctx, c, routePath, filter.values())
+}
+
+func (c *Client) GetRoute(id string) (*Route, error) {
+	return c.GetRouteCtx(context.Background(), id)
+}
+
+func (c *Client) GetRouteCtx(ctx context.Context, id string) (*Route, error) {
+	ret := &Route{}
+	err := c.doRequest(ctx, "GET", buildPath(routePath, id), nil, ret)
+	return ret, err
+}
+
+type CreateRouteOptions struct {
+	EscalationChainID string
+	//RoutingType defaults to RouteRoutingTypeRegex
+	RoutingType RouteRoutingType
+	//If Position is nil, the route is added after the other non-default routes
+	//of the integration
+	Position *int
+	Slack    *RouteSlackSettings
+	Telegram *RouteTelegramSettings
+}
+
+func (c *Client) CreateRoute(
+	integrationID string,
+	routingRegex string,
+	opts *CreateRouteOptions,
+) (*Route, error) {
+	return c.CreateRouteCtx(context.Background(), integrationID, routingRegex, opts)
+}
+
+func (c *Client) CreateRouteCtx(
+	ctx context.Context,
+	integrationID string,
+	routingRegex string,
+	opts *CreateRouteOptions,
+) (*Route, error) {
+
+	requestBody := struct {
+		IntegrationID     string                 `json:"integration_id"`
+		RoutingRegex      string                 `json:"routing_regex"`
+		EscalationChainID string                 `json:"escalation_chain_id,omitempty"`
+		RoutingType       RouteRoutingType       `json:"routing_type,omitempty"`
+		Position          *int                   `json:"position,omitempty"`
+		ManualOrder       bool                   `json:"manual_order,omitempty"`
+		Slack             *RouteSlackSettings    `json:"slack,omitempty"`
+		Telegram          *RouteTelegramSettings `json:"telegram,omitempty"`
+	}{
+		IntegrationID: integrationID,
+		RoutingRegex:  routingRegex,
+	}
+
+	if opts != nil {
+		requestBody.EscalationChainID = opts.EscalationChainID
+		requestBody.RoutingType = opts.RoutingType
+		requestBody.Position = opts.Position
+		requestBody.ManualOrder = opts.Position != nil
+		requestBody.Slack = opts.Slack
+		requestBody.Telegram = opts.Telegram
+	}
+
+	ret := &Route{}
+	err := c.doRequest(ctx, "POST", routePath, &requestBody, ret)
+	return ret, err
+}
+
+// UpdateRouteOptions holds the fields to change on a route. Only non-nil fields
+// are sent.
+type UpdateRouteOptions struct {
+	EscalationChainID *string
+	RoutingType       *RouteRoutingType
+	RoutingRegex      *string
+	Position          *int
+	Slack             *RouteSlackSettings
+	Telegram          *RouteTelegramSettings
+}
+
+func (c *Client) UpdateRoute(id string, opts *UpdateRouteOptions) (*Route, error) {
+	return c.UpdateRouteCtx(context.Background(), id, opts)
+}
+
+func (c *Client) UpdateRouteCtx(
+	ctx context.Context,
+	id string,
+	opts *UpdateRouteOptions,
+) (*Route, error) {
+
+	requestBody := struct {
+		EscalationChainID *string                `json:"escalation_chain_id,omitempty"`
+		RoutingType       *RouteRoutingType      `json:"routing_type,omitempty"`
+		RoutingRegex      *string                `json:"routing_regex,omitempty"`
+		Position          *int                   `json:"position,omitempty"`
+		ManualOrder       bool                   `json:"manual_order,omitempty"`
+		Slack             *RouteSlackSettings    `json:"slack,omitempty"`
+		Telegram          *RouteTelegramSettings `json:"telegram,omitempty"`
+	}{}
+
+	if opts != nil {
+		requestBody.EscalationChainID = opts.EscalationChainID
+		requestBody.RoutingType = opts.RoutingType
+		requestBody.RoutingRegex = opts.RoutingRegex
+		requestBody.Position = opts.Position
+		requestBody.ManualOrder = opts.Position != nil
+		requestBody.Slack = opts.Slack
+		requestBody.Telegram = opts.Telegram
+	}
+
+	ret := &Route{}
+	err := c.doRequest(ctx, "PUT", buildPath(routePath, id), &requestBody, ret)
+	return ret, err
+}
+
+func (c *Client) DeleteRoute(id string) error {
+	return c.DeleteRouteCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteRouteCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(routePath, id), nil, nil)
+}
@@ -1,7 +1,9 @@
 package oncall
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"net/url"
 	"strings"
 	"time"
@@ -106,6 +108,7 @@ func (s *Schedule) UnmarshalJSON(b []byte) error {
 }
 
 type ScheduleSlackMetadata struct {
+	//ChannelID is the Slack ID of the channel. See Client.FindSlackChannelID.
 	ChannelID   string `json:"channel_id"`
 	UserGroupID string `json:"user_group_id"`
 }
@@ -189,6 +192,15 @@ func (c *Client) CreateSchedule(
 	cal ScheduleCalendar,
 	opts *CreateScheduleOptions,
 ) (*Schedule, error) {
+	return c.CreateScheduleCtx(context.Background(), name, cal, opts)
+}
+
+func (c *Client) CreateScheduleCtx(
+	ctx context.Context,
+	name string,
+	cal ScheduleCalendar,
+	opts *CreateScheduleOptions,
+) (*Schedule, error) {
 
 	ret := &Schedule{}
 
@@ -211,6 +223,7 @@ func (c *Client) CreateSchedule(
 	}
 
 	err := c.doRequest(
+		ctx,
 		"POST",
 		schedulePath,
 		schedOut,
@@ -220,38 +233,272 @@ func (c *Client) CreateSchedule(
 }
 
 type ScheduleFilter struct {
-	Name string
+	Name   string
+	TeamID string
+}
+
+func (f *ScheduleFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.Name != "" {
+			values.Set("name", f.Name)
+		}
+
+		if f.TeamID != "" {
+			values.Set("team_id", f.TeamID)
+		}
+	}
+
+	return values
 }
 
 func (c *Client) ListSchedulesByPage(
 	page int,
 	filter *ScheduleFilter,
 ) (*PaginatedResponse[Schedule], error) {
+	return c.ListSchedulesByPageCtx(context.Background(), page, filter)
+}
 
-	values := url.Values{}
-	if filter != nil {
-		if filter.Name != "" {
-			values.Set("name", filter.Name)
-		}
-	}
-
-	return getPage[Schedule](c, page, schedulePath, values)
+func (c *Client) ListSchedulesByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *ScheduleFilter,
+) (*PaginatedResponse[Schedule], error) {
+	return getPage[Schedule](ctx, c, page, schedulePath, filter.values())
 }
 
 func (c *Client) ListSchedules(
 	filter *ScheduleFilter,
 ) ([]Schedule, error) {
-	return paginate(c.ListSchedulesByPage, filter)
+	return c.ListSchedulesCtx(context.Background(), filter)
+}
+
+func (c *Client) ListSchedulesCtx(
+	ctx context.Context,
+	filter *ScheduleFilter,
+) ([]Schedule, error) {
+	return paginate(c.SchedulesIterCtx(ctx, filter))
+}
+
+func (c *Client) SchedulesIter(filter *ScheduleFilter) *Iterator[Schedule] {
+	return c.SchedulesIterCtx(context.Background(), filter)
+}
+
+func (c *Client) SchedulesIterCtx(ctx context.Context, filter *ScheduleFilter) *Iterator[Schedule] {
+	return newIterator[Schedule](ctx, c, schedulePath, filter.values())
 }
 
 func (c *Client) GetSchedule(id string) (*Schedule, error) {
+	return c.GetScheduleCtx(context.Background(), id)
+}
+
+func (c *Client) GetScheduleCtx(ctx context.Context, id string) (*Schedule, error) {
 	ret := &Schedule{}
-	err := c.doRequest("GET", buildPath(schedulePath, id), nil, ret)
+	err := c.doRequest(ctx, "GET", buildPath(schedulePath, id), nil, ret)
 	return ret, err
 }
 
 func (c *Client) DeleteSchedule(id string) error {
-	return c.doRequest("DELETE", buildPath(schedulePath, id), nil, nil)
+	return c.DeleteScheduleCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteScheduleCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(schedulePath, id), nil, nil)
 }
 
-//TODO: Write UpdateSchedule
+// UpdateScheduleOptions holds the fields to change on a schedule. Only non-nil
+// fields are sent.
+type UpdateScheduleOptions struct {
+	Name             *string
+	TeamID           *string
+	TimeZone         *time.Location
+	ICalOverridesURL *string
+	Slack            *ScheduleSlackMetadata
+	//If Calendar is non-nil, its fields are sent. It must be of the same type as
+	//the calendar of the schedule being updated.
+	Calendar ScheduleCalendar
+}
+
+func (c *Client) UpdateSchedule(id string, opts *UpdateScheduleOptions) (*Schedule, error) {
+	return c.UpdateScheduleCtx(context.Background(), id, opts)
+}
+
+func (c *Client) UpdateScheduleCtx(
+	ctx context.Context,
+	id string,
+	opts *UpdateScheduleOptions,
+) (*Schedule, error) {
+
+	requestBody := map[string]interface{}{}
+	if opts != nil {
+		if opts.Calendar != nil {
+			inter, err := json.Marshal(&opts.Calendar)
+			if err != nil {
+				return nil, err
+			}
+
+			err = json.Unmarshal(inter, &requestBody)
+			if err != nil {
+				return nil, err
+			}
+
+			requestBody["type"] = opts.Calendar.ScheduleCalendarType().String()
+		}
+
+		if opts.Name != nil {
+			requestBody["name"] = *opts.Name
+		}
+		if opts.TeamID != nil {
+			requestBody["team_id"] = *opts.TeamID
+		}
+		if opts.TimeZone != nil {
+			requestBody["time_zone"] = opts.TimeZone.String()
+		}
+		if opts.ICalOverridesURL != nil {
+			requestBody["ical_url_overrides"] = *opts.ICalOverridesURL
+		}
+		if opts.Slack != nil {
+			requestBody["slack"] = opts.Slack
+		}
+	}
+
+	ret := &Schedule{}
+	err := c.doRequest(ctx, "PUT", buildPath(schedulePath, id), &requestBody, ret)
+	return ret, err
+}
+
+// FinalShift is a period in which a user is on call in the final timeline of a
+// schedule, after overrides have been applied.
+type FinalShift struct {
+	UserID       string
+	UserEmail    string
+	UserUsername string
+	Start        time.Time
+	End          time.Time
+}
+
+type finalShiftRaw struct {
+	UserID       string `json:"user_pk"`
+	UserEmail    string `json:"user_email"`
+	UserUsername string `json:"user_username"`
+	Start        string `json:"shift_start"`
+	End          string `json:"shift_end"`
+}
+
+func (f *FinalShift) UnmarshalJSON(b []byte) error {
+	raw := finalShiftRaw{}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	*f = FinalShift{
+		UserID:       raw.UserID,
+		UserEmail:    raw.UserEmail,
+		UserUsername: raw.UserUsername,
+	}
+
+	f.Start, err = timeFromString(raw.Start)
+	if err != nil {
+		return err
+	}
+
+	f.End, err = timeFromString(raw.End)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (f *FinalShift) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(&finalShiftRaw{
+		UserID:       f.UserID,
+		UserEmail:    f.UserEmail,
+		UserUsername: f.UserUsername,
+		Start:        timeToString(f.Start.UTC()),
+		End:          timeToString(f.End.UTC()),
+	})
+}
+
+const finalShiftDateLayout = "2006-01-02"
+
+// GetScheduleFinalShifts returns the final shifts of the schedule which overlap
+// the window from start to end, in chronological order. The start and end of
+// each shift are given in the TimeZone of the schedule.
+func (c *Client) GetScheduleFinalShifts(
+	scheduleID string,
+	start, end time.Time,
+) ([]FinalShift, error) {
+	return c.GetScheduleFinalShiftsCtx(context.Background(), scheduleID, start, end)
+}
+
+func (c *Client) GetScheduleFinalShiftsCtx(
+	ctx context.Context,
+	scheduleID string,
+	start, end time.Time,
+) ([]FinalShift, error) {
+
+	sched, err := c.GetScheduleCtx(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	//The API only takes dates, so widen the window to whole days and trim the
+	//shifts which fall outside of it afterward
+	values := url.Values{}
+	values.Set("start_date", start.UTC().Format(finalShiftDateLayout))
+	values.Set("end_date", end.UTC().Format(finalShiftDateLayout))
+
+	shifts, err := paginate(newIterator[FinalShift](
+		ctx,
+		c,
+		buildPath(schedulePath, scheduleID, "final_shifts"),
+		values,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]FinalShift, 0, len(shifts))
+	for _, shift := range shifts {
+		if !shift.End.After(start) || !shift.Start.Before(end) {
+			continue
+		}
+
+		shift.Start = shift.Start.In(sched.TimeZone)
+		shift.End = shift.End.In(sched.TimeZone)
+		ret = append(ret, shift)
+	}
+
+	return ret, nil
+}
+
+// GetScheduleICal fetches the iCalendar export of the schedule and parses its
+// events.
+func (c *Client) GetScheduleICal(id string) ([]ICalEvent, error) {
+	return c.GetScheduleICalCtx(context.Background(), id)
+}
+
+func (c *Client) GetScheduleICalCtx(ctx context.Context, id string) ([]ICalEvent, error) {
+	path := buildPath(schedulePath, id, "export")
+	resp, err := c.CurlCtx(ctx, "GET", path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 != 2 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError("GET", path, resp.StatusCode, respBody)
+	}
+
+	return ParseICal(resp.Body)
+}
@@ -0,0 +1,258 @@
+package oncall
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const shiftSwapPath = "shift_swaps"
+
+// ShiftSwap is a request by the Beneficiary to have someone else take their
+// shifts of a schedule between SwapStart and SwapEnd.
+type ShiftSwap struct {
+	ID         string
+	ScheduleID string
+	SwapStart  time.Time
+	SwapEnd    time.Time
+	//BeneficiaryID is the ID of the user giving away their shifts
+	BeneficiaryID string
+	//BenefactorID is the ID of the user who took the shifts. It is empty if the
+	//swap has not been taken.
+	BenefactorID string
+	Status       ShiftSwapStatus
+	Description  string
+	CreatedAt    time.Time
+}
+
+type shiftSwapRaw struct {
+	ID          string          `json:"id"`
+	Schedule    string          `json:"schedule"`
+	SwapStart   string          `json:"swap_start"`
+	SwapEnd     string          `json:"swap_end"`
+	Beneficiary string          `json:"beneficiary"`
+	Benefactor  string          `json:"benefactor,omitempty"`
+	Status      ShiftSwapStatus `json:"status"`
+	Description string          `json:"description,omitempty"`
+	CreatedAt   string          `json:"created_at"`
+}
+
+func (s *ShiftSwap) UnmarshalJSON(b []byte) error {
+	raw := shiftSwapRaw{}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	*s = ShiftSwap{
+		ID:            raw.ID,
+		ScheduleID:    raw.Schedule,
+		BeneficiaryID: raw.Beneficiary,
+		BenefactorID:  raw.Benefactor,
+		Status:        raw.Status,
+		Description:   raw.Description,
+	}
+
+	s.SwapStart, err = timeFromString(raw.SwapStart)
+	if err != nil {
+		return err
+	}
+
+	s.SwapEnd, err = timeFromString(raw.SwapEnd)
+	if err != nil {
+		return err
+	}
+
+	s.CreatedAt, err = timeFromString(raw.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ShiftSwap) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(&shiftSwapRaw{
+		ID:          s.ID,
+		Schedule:    s.ScheduleID,
+		SwapStart:   timeToString(s.SwapStart),
+		SwapEnd:     timeToString(s.SwapEnd),
+		Beneficiary: s.BeneficiaryID,
+		Benefactor:  s.BenefactorID,
+		Status:      s.Status,
+		Description: s.Description,
+		CreatedAt:   timeToString(s.CreatedAt),
+	})
+}
+
+type ShiftSwapStatus string
+
+const (
+	ShiftSwapStatusOpen    ShiftSwapStatus = "open"
+	ShiftSwapStatusTaken   ShiftSwapStatus = "taken"
+	ShiftSwapStatusPastDue ShiftSwapStatus = "past_due"
+	ShiftSwapStatusDeleted ShiftSwapStatus = "deleted"
+)
+
+type ShiftSwapFilter struct {
+	ScheduleID    string
+	BeneficiaryID string
+	BenefactorID  string
+	//If OpenOnly is true, only swaps which have not been taken and have not
+	//started are returned
+	OpenOnly bool
+}
+
+func (f *ShiftSwapFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.ScheduleID != "" {
+			values.Set("schedule_id", f.ScheduleID)
+		}
+
+		if f.BeneficiaryID != "" {
+			values.Set("beneficiary", f.BeneficiaryID)
+		}
+
+		if f.BenefactorID != "" {
+			values.Set("benefactor", f.BenefactorID)
+		}
+
+		if f.OpenOnly {
+			values.Set("open_only", strconv.FormatBool(f.OpenOnly))
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListShiftSwapsByPage(
+	page int,
+	filter *ShiftSwapFilter,
+) (*PaginatedResponse[ShiftSwap], error) {
+	return c.ListShiftSwapsByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListShiftSwapsByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *ShiftSwapFilter,
+) (*PaginatedResponse[ShiftSwap], error) {
+	return getPage[ShiftSwap](ctx, c, page, shiftSwapPath, filter.values())
+}
+
+func (c *Client) ListShiftSwaps(filter *ShiftSwapFilter) ([]ShiftSwap, error) {
+	return c.ListShiftSwapsCtx(context.Background(), filter)
+}
+
+func (c *Client) ListShiftSwapsCtx(ctx context.Context, filter *ShiftSwapFilter) ([]ShiftSwap, error) {
+	return paginate(c.ShiftSwapsIterCtx(ctx, filter))
+}
+
+func (c *Client) ShiftSwapsIter(filter *ShiftSwapFilter) *Iterator[ShiftSwap] {
+	return c.ShiftSwapsIterCtx(context.Background(), filter)
+}
+
+func (c *Client) ShiftSwapsIterCtx(ctx context.Context, filter *ShiftSwapFilter) *Iterator[ShiftSwap] {
+	return newIterator[ShiftSwap](ctx, c, shiftSwapPath, filter.values())
+}
+
+func (c *Client) GetShiftSwap(id string) (*ShiftSwap, error) {
+	return c.GetShiftSwapCtx(context.Background(), id)
+}
+
+func (c *Client) GetShiftSwapCtx(ctx context.Context, id string) (*ShiftSwap, error) {
+	ret := &ShiftSwap{}
+	err := c.doRequest(ctx, "GET", buildPath(shiftSwapPath, id), nil, ret)
+	return ret, err
+}
+
+type CreateShiftSwapOptions struct {
+	Description string
+}
+
+// CreateShiftSwap requests that the shifts of the beneficiary in the schedule
+// between swapStart and swapEnd be taken by someone else.
+func (c *Client) CreateShiftSwap(
+	scheduleID string,
+	beneficiaryID string,
+	swapStart time.Time,
+	swapEnd time.Time,
+	opts *CreateShiftSwapOptions,
+) (*ShiftSwap, error) {
+	return c.CreateShiftSwapCtx(
+		context.Background(),
+		scheduleID,
+		beneficiaryID,
+		swapStart,
+		swapEnd,
+		opts,
+	)
+}
+
+func (c *Client) CreateShiftSwapCtx(
+	ctx context.Context,
+	scheduleID string,
+	beneficiaryID string,
+	swapStart time.Time,
+	swapEnd time.Time,
+	opts *CreateShiftSwapOptions,
+) (*ShiftSwap, error) {
+
+	requestBody := struct {
+		Schedule    string `json:"schedule"`
+		Beneficiary string `json:"beneficiary"`
+		SwapStart   string `json:"swap_start"`
+		SwapEnd     string `json:"swap_end"`
+		Description string `json:"description,omitempty"`
+	}{
+		Schedule:    scheduleID,
+		Beneficiary: beneficiaryID,
+		SwapStart:   timeToString(swapStart),
+		SwapEnd:     timeToString(swapEnd),
+	}
+
+	if opts != nil {
+		requestBody.Description = opts.Description
+	}
+
+	ret := &ShiftSwap{}
+	err := c.doRequest(ctx, "POST", shiftSwapPath, &requestBody, ret)
+	return ret, err
+}
+
+// TakeShiftSwap has the benefactor take the shifts of an open shift swap.
+func (c *Client) TakeShiftSwap(id, benefactorID string) (*ShiftSwap, error) {
+	return c.TakeShiftSwapCtx(context.Background(), id, benefactorID)
+}
+
+func (c *Client) TakeShiftSwapCtx(
+	ctx context.Context,
+	id string,
+	benefactorID string,
+) (*ShiftSwap, error) {
+
+	requestBody := struct {
+		Benefactor string `json:"benefactor"`
+	}{
+		Benefactor: benefactorID,
+	}
+
+	ret := &ShiftSwap{}
+	err := c.doRequest(ctx, "POST", buildPath(shiftSwapPath, id, "take"), &requestBody, ret)
+	return ret, err
+}
+
+func (c *Client) DeleteShiftSwap(id string) error {
+	return c.DeleteShiftSwapCtx(context.Background(), id)
+}
+
+func (c *Client) DeleteShiftSwapCtx(ctx context.Context, id string) error {
+	return c.doRequest(ctx, "DELETE", buildPath(shiftSwapPath, id), nil, nil)
+}
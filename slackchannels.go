@@ -0,0 +1,100 @@
+package oncall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const slackChannelPath = "slack_channels"
+
+// ErrSlackChannelNotFound is returned by FindSlackChannelID when no channel
+// has the requested name.
+var ErrSlackChannelNotFound = errors.New("slack channel not found")
+
+type SlackChannel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	//SlackID is the ID of the channel in Slack. This is the ID expected by
+	//ScheduleSlackMetadata.ChannelID.
+	SlackID string `json:"slack_id"`
+}
+
+type SlackChannelFilter struct {
+	ChannelName string
+}
+
+func (f *SlackChannelFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.ChannelName != "" {
+			values.Set("channel_name", f.ChannelName)
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListSlackChannelsByPage(
+	page int,
+	filter *SlackChannelFilter,
+) (*PaginatedResponse[SlackChannel], error) {
+	return c.ListSlackChannelsByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListSlackChannelsByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *SlackChannelFilter,
+) (*PaginatedResponse[SlackChannel], error) {
+	return getPage[SlackChannel](ctx, c, page, slackChannelPath, filter.values())
+}
+
+func (c *Client) ListSlackChannels(filter *SlackChannelFilter) ([]SlackChannel, error) {
+	return c.ListSlackChannelsCtx(context.Background(), filter)
+}
+
+func (c *Client) ListSlackChannelsCtx(
+	ctx context.Context,
+	filter *SlackChannelFilter,
+) ([]SlackChannel, error) {
+	return paginate(c.SlackChannelsIterCtx(ctx, filter))
+}
+
+func (c *Client) SlackChannelsIter(filter *SlackChannelFilter) *Iterator[SlackChannel] {
+	return c.SlackChannelsIterCtx(context.Background(), filter)
+}
+
+func (c *Client) SlackChannelsIterCtx(
+	ctx context.Context,
+	filter *SlackChannelFilter,
+) *Iterator[SlackChannel] {
+	return newIterator[SlackChannel](ctx, c, slackChannelPath, filter.values())
+}
+
+// FindSlackChannelID returns the Slack ID of the channel with the given name,
+// suitable for use in ScheduleSlackMetadata.ChannelID. A leading "#" in name is
+// ignored. If no channel has that exact name, an error wrapping
+// ErrSlackChannelNotFound is returned.
+func (c *Client) FindSlackChannelID(name string) (string, error) {
+	return c.FindSlackChannelIDCtx(context.Background(), name)
+}
+
+func (c *Client) FindSlackChannelIDCtx(ctx context.Context, name string) (string, error) {
+	name = strings.TrimPrefix(name, "#")
+
+	it := c.SlackChannelsIterCtx(ctx, &SlackChannelFilter{ChannelName: name})
+	for it.Next() {
+		if it.Value().Name == name {
+			return it.Value().SlackID, nil
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrSlackChannelNotFound, name)
+}
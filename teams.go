@@ -0,0 +1,69 @@
+package oncall
+
+import (
+	"context"
+	"net/url"
+)
+
+const teamPath = "teams"
+
+type Team struct {
+	ID        string `json:"id"`
+	GrafanaID int    `json:"grafana_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type TeamFilter struct {
+	Name string
+}
+
+func (f *TeamFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.Name != "" {
+			values.Set("name", f.Name)
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListTeamsByPage(page int, filter *TeamFilter) (*PaginatedResponse[Team], error) {
+	return c.ListTeamsByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListTeamsByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *TeamFilter,
+) (*PaginatedResponse[Team], error) {
+	return getPage[Team](ctx, c, page, teamPath, filter.values())
+}
+
+func (c *Client) ListTeams(filter *TeamFilter) ([]Team, error) {
+	return c.ListTeamsCtx(context.Background(), filter)
+}
+
+func (c *Client) ListTeamsCtx(ctx context.Context, filter *TeamFilter) ([]Team, error) {
+	return paginate(c.TeamsIterCtx(ctx, filter))
+}
+
+func (c *Client) TeamsIter(filter *TeamFilter) *Iterator[Team] {
+	return c.TeamsIterCtx(context.Background(), filter)
+}
+
+func (c *Client) TeamsIterCtx(ctx context.Context, filter *TeamFilter) *Iterator[Team] {
+	return newIterator[Team](ctx, c, teamPath, filter.values())
+}
+
+func (c *Client) GetTeam(id string) (*Team, error) {
+	return c.GetTeamCtx(context.Background(), id)
+}
+
+func (c *Client) GetTeamCtx(ctx context.Context, id string) (*Team, error) {
+	ret := &Team{}
+	err := c.doRequest(ctx, "GET", buildPath(teamPath, id), nil, ret)
+	return ret, err
+}
@@ -0,0 +1,88 @@
+package oncall
+
+import (
+	"context"
+	"net/url"
+)
+
+const userGroupPath = "user_groups"
+
+type UserGroup struct {
+	ID    string                 `json:"id"`
+	Type  UserGroupType          `json:"type"`
+	Slack UserGroupSlackMetadata `json:"slack"`
+}
+
+type UserGroupSlackMetadata struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Handle string `json:"handle"`
+}
+
+type UserGroupType string
+
+const (
+	UserGroupTypeSlackBased UserGroupType = "slack_based"
+)
+
+type UserGroupFilter struct {
+	SlackHandle string
+}
+
+func (f *UserGroupFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.SlackHandle != "" {
+			values.Set("slack_handle", f.SlackHandle)
+		}
+	}
+
+	return values
+}
+
+func (c *Client) ListUserGroupsByPage(
+	page int,
+	filter *UserGroupFilter,
+) (*PaginatedResponse[UserGroup], error) {
+	return c.ListUserGroupsByPageCtx(context.Background(), page, filter)
+}
+
+func (c *Client) ListUserGroupsByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *UserGroupFilter,
+) (*PaginatedResponse[UserGroup], error) {
+	return getPage[UserGroup](ctx, c, page, userGroupPath, filter.values())
+}
+
+func (c *Client) ListUserGroups(filter *UserGroupFilter) ([]UserGroup, error) {
+	return c.ListUserGroupsCtx(context.Background(), filter)
+}
+
+func (c *Client) ListUserGroupsCtx(
+	ctx context.Context,
+	filter *UserGroupFilter,
+) ([]UserGroup, error) {
+	return paginate(c.UserGroupsIterCtx(ctx, filter))
+}
+
+func (c *Client) UserGroupsIter(filter *UserGroupFilter) *Iterator[UserGroup] {
+	return c.UserGroupsIterCtx(context.Background(), filter)
+}
+
+func (c *Client) UserGroupsIterCtx(
+	ctx context.Context,
+	filter *UserGroupFilter,
+) *Iterator[UserGroup] {
+	return newIterator[UserGroup](ctx, c, userGroupPath, filter.values())
+}
+
+func (c *Client) GetUserGroup(id string) (*UserGroup, error) {
+	return c.GetUserGroupCtx(context.Background(), id)
+}
+
+func (c *Client) GetUserGroupCtx(ctx context.Context, id string) (*UserGroup, error) {
+	ret := &UserGroup{}
+	err := c.doRequest(ctx, "GET", buildPath(userGroupPath, id), nil, ret)
+	return ret, err
+}
@@ -1,6 +1,9 @@
 package oncall
 
-import "net/url"
+import (
+	"context"
+	"net/url"
+)
 
 const userPath = "users"
 
@@ -30,29 +33,59 @@ type UserFilter struct {
 	Username string
 }
 
+func (f *UserFilter) values() url.Values {
+	values := url.Values{}
+	if f != nil {
+		if f.Username != "" {
+			values.Set("username", f.Username)
+		}
+	}
+
+	return values
+}
+
 func (c *Client) ListUsersByPage(
 	page int,
 	filter *UserFilter,
 ) (*PaginatedResponse[User], error) {
+	return c.ListUsersByPageCtx(context.Background(), page, filter)
+}
 
-	values := url.Values{}
-	if filter != nil {
-		if filter.Username != "" {
-			values.Set("username", filter.Username)
-		}
-	}
-
-	return getPage[User](c, page, userPath, values)
+func (c *Client) ListUsersByPageCtx(
+	ctx context.Context,
+	page int,
+	filter *UserFilter,
+) (*PaginatedResponse[User], error) {
+	return getPage[User](ctx, c, page, userPath, filter.values())
 }
 
 func (c *Client) ListUsers(
 	filter *UserFilter,
 ) ([]User, error) {
-	return paginate(c.ListUsersByPage, filter)
+	return c.ListUsersCtx(context.Background(), filter)
+}
+
+func (c *Client) ListUsersCtx(
+	ctx context.Context,
+	filter *UserFilter,
+) ([]User, error) {
+	return paginate(c.UsersIterCtx(ctx, filter))
+}
+
+func (c *Client) UsersIter(filter *UserFilter) *Iterator[User] {
+	return c.UsersIterCtx(context.Background(), filter)
+}
+
+func (c *Client) UsersIterCtx(ctx context.Context, filter *UserFilter) *Iterator[User] {
+	return newIterator[User](ctx, c, userPath, filter.values())
 }
 
 func (c *Client) GetUser(id string) (*User, error) {
+	return c.GetUserCtx(context.Background(), id)
+}
+
+func (c *Client) GetUserCtx(ctx context.Context, id string) (*User, error) {
 	ret := &User{}
-	err := c.doRequest("GET", buildPath(userPath, id), nil, ret)
+	err := c.doRequest(ctx, "GET", buildPath(userPath, id), nil, ret)
 	return ret, err
 }
@@ -1,6 +1,7 @@
 package oncall
 
 import (
+	"context"
 	"net/url"
 	"strconv"
 	"strings"
@@ -10,10 +11,29 @@ import (
 const isoTimeLayout = "2006-01-02T15:04:05Z"
 const isoTimeOfDayLayout = "15:04:05Z"
 
-func timeToString(t time.Time) string            { return t.Format(isoTimeLayout) }
+// timeToString converts t to UTC first, since the layout always claims UTC
+func timeToString(t time.Time) string            { return t.UTC().Format(isoTimeLayout) }
 func timeOfDayToString(t time.Time) string       { return t.Format(isoTimeOfDayLayout) }
 func timeFromString(s string) (time.Time, error) { return time.Parse(isoTimeLayout, s) }
 
+// optionalTimeToString and optionalTimeFromString map the zero time to and from
+// the empty string, for fields the API may leave null.
+func optionalTimeToString(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return timeToString(t)
+}
+
+func optionalTimeFromString(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	return timeFromString(s)
+}
+
 func buildPath(segments ...string) string {
 	sanitized := make([]string, len(segments))
 	for i, segment := range segments {
@@ -23,40 +43,32 @@ func buildPath(segments ...string) string {
 	return strings.Join(sanitized, "/")
 }
 
-func paginate[T any, F any](
-	fn func(page int, filter *F) (*PaginatedResponse[T], error),
-	filter *F,
-) ([]T, error) {
-
-	var page int
-	var ret []T
-	pageResp, err := fn(page, filter)
-	if err != nil {
-		return nil, err
+func paginate[T any](it *Iterator[T]) ([]T, error) {
+	ret := []T{}
+	for it.Next() {
+		ret = append(ret, it.Value())
 	}
 
-	ret = make([]T, 0, pageResp.Count)
-	ret = append(ret, pageResp.Results...)
-
-	for pageResp.Next != "" {
-		page++
-		pageResp, err = fn(page, filter)
-		if err != nil {
-			return nil, err
-		}
-
-		ret = append(ret, pageResp.Results...)
+	if err := it.Err(); err != nil {
+		return nil, err
 	}
 
 	return ret, nil
 }
 
-func getPage[T any](c *Client, page int, path string, vals url.Values) (*PaginatedResponse[T], error) {
+func getPage[T any](
+	ctx context.Context,
+	c *Client,
+	page int,
+	path string,
+	vals url.Values,
+) (*PaginatedResponse[T], error) {
+
 	if page > 0 {
 		vals.Set("page", strconv.Itoa(page))
 	}
 
 	ret := &PaginatedResponse[T]{}
-	err := c.doRequest("GET", path, vals, &ret)
+	err := c.doRequest(ctx, "GET", path, vals, &ret)
 	return ret, err
 }